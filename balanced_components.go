@@ -0,0 +1,40 @@
+package cuid2
+
+import (
+	"strings"
+)
+
+const (
+	// Number of base36 characters of a millisecond timestamp until the year
+	// 5188
+	balancedTimeWidth = 9
+
+	// Number of base36 characters of MaxSessionCount
+	balancedCounterWidth = 6
+)
+
+// Zero-pads the timestamp and session count to fixed widths before they enter
+// the hash input, so that the length of the hash input does not vary with the
+// magnitude of either component.
+//
+// Values wider than the padding, such as the counts of a ClockCounter, are
+// left as is. A counter width set with WithCounterWidth takes precedence over
+// the padding of the session count. The hash spreads its input uniformly
+// either way, so this changes the distribution of Cuids only in theory, see
+// TestBalancedComponentsDistribution. By default, both components have a
+// variable width.
+func WithBalancedComponents() Option {
+	return func(config *Config) error {
+		config.BalancedComponents = true
+		return nil
+	}
+}
+
+// Left-pads a base36 value with zeros up to the given width
+func padComponent(value string, width int) string {
+	if len(value) >= width {
+		return value
+	}
+
+	return strings.Repeat("0", width-len(value)) + value
+}
@@ -0,0 +1,104 @@
+package cuid2
+
+import (
+	"log"
+	"math/big"
+	"testing"
+	"time"
+)
+
+func TestBalancedComponents(t *testing.T) {
+	inputs := []string{}
+
+	generator, err := NewGenerator(
+		WithBalancedComponents(),
+		WithSessionCounter(NewSessionCounter(0)),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(36) }),
+		WithFingerprint("node-1"),
+		WithHashInputObserver(func(input string) {
+			inputs = append(inputs, input)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if cuid := generator.Generate(); !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid, but got %v", cuid)
+	}
+
+	input := inputs[0]
+	if input[:balancedTimeWidth] != "000000010" {
+		t.Fatalf("Expected the timestamp to be zero-padded, but got hash input %v", input)
+	}
+
+	salt := input[balancedTimeWidth : len(input)-balancedCounterWidth-len("node-1")]
+	if count := input[balancedTimeWidth+len(salt) : len(input)-len("node-1")]; count != "000001" {
+		t.Fatalf("Expected the session count to be zero-padded, but got %v", count)
+	}
+
+	if padded := padComponent("1000000", balancedCounterWidth); padded != "1000000" {
+		t.Fatalf("Expected a wider value to be left as is, but got %v", padded)
+	}
+
+	inputs = nil
+	narrow, err := NewGenerator(
+		WithBalancedComponents(),
+		WithCounterWidth(3),
+		WithSessionCounter(NewSessionCounter(5)),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(36) }),
+		WithFingerprint("node-1"),
+		WithHashInputObserver(func(input string) {
+			inputs = append(inputs, input)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	narrow.Generate()
+
+	input = inputs[0]
+	if count := input[len(input)-3-len("node-1") : len(input)-len("node-1")]; count != "006" {
+		t.Fatalf("Expected the counter width to take precedence over the padding, but got hash input %v", input)
+	}
+
+	if len(input) != balancedTimeWidth+len(salt)+3+len("node-1") {
+		t.Fatalf("Expected the session count not to be padded beyond the counter width, but got hash input %v", input)
+	}
+}
+
+func TestBalancedComponentsDistribution(t *testing.T) {
+	// Each bin holds about 1000 Cuids with a standard deviation of about 31, so
+	// a tolerance of 20% keeps false failures practically impossible
+	n := 20000
+	tolerance := 0.2
+
+	testCases := map[string][]Option{
+		"variable width": {WithSessionCounter(NewSessionCounter(0))},
+		"balanced":       {WithSessionCounter(NewSessionCounter(0)), WithBalancedComponents()},
+	}
+
+	for name, options := range testCases {
+		generator, err := NewGenerator(options...)
+		if err != nil {
+			t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+		}
+
+		numbers := []big.Int{}
+		for len(numbers) < n {
+			number, _ := BodyValue(generator.Generate())
+			numbers = append(numbers, *number)
+		}
+
+		histogram := buildHistogram(numbers, 20)
+		log.Printf("Histogram (%v): %v", name, histogram)
+
+		expectedBinSize := float64(n / len(histogram))
+		for _, binSize := range histogram {
+			if binSize < expectedBinSize*(1-tolerance) || binSize > expectedBinSize*(1+tolerance) {
+				t.Fatalf("Expected bin sizes (%v) of %v Cuids to be within %v of %v", histogram, name, tolerance, expectedBinSize)
+			}
+		}
+	}
+}
@@ -2,15 +2,19 @@ package cuid2
 
 import (
 	"log"
+	"math/rand"
+	"runtime"
+	"strings"
+	"sync/atomic"
 	"testing"
 )
 
 var result string
 
-func benchmarkGenerate(b *testing.B, length int) {
+func benchmarkGenerate(b *testing.B, length int, options ...Option) {
 	var id string
 
-	generate, err := Init(WithLength(length))
+	generate, err := Init(append([]Option{WithLength(length)}, options...)...)
 	if err != nil {
 		log.Fatalln("Error: Could not initialise Cuid2 generator")
 	}
@@ -26,3 +30,126 @@ func BenchmarkGenerate8(b *testing.B)  { benchmarkGenerate(b, 8) }
 func BenchmarkGenerate16(b *testing.B) { benchmarkGenerate(b, 16) }
 func BenchmarkGenerate24(b *testing.B) { benchmarkGenerate(b, 24) }
 func BenchmarkGenerate32(b *testing.B) { benchmarkGenerate(b, 32) }
+
+func BenchmarkGenerateWithEntropyPool8(b *testing.B) { benchmarkGenerate(b, 8, WithEntropyPool(4096)) }
+func BenchmarkGenerateWithEntropyPool32(b *testing.B) {
+	benchmarkGenerate(b, 32, WithEntropyPool(4096))
+}
+
+var isCuidResult bool
+
+func benchmarkIsCuid(b *testing.B, cuid string) {
+	var valid bool
+
+	for n := 0; n < b.N; n++ {
+		valid = IsCuid(cuid)
+	}
+
+	isCuidResult = valid
+}
+
+func BenchmarkIsCuidValidShort(b *testing.B)   { benchmarkIsCuid(b, "yi") }
+func BenchmarkIsCuidValidMax(b *testing.B)     { benchmarkIsCuid(b, "yi7rqj1trke4ynzfqcx3nxnq0tgu6v5z") }
+func BenchmarkIsCuidInvalidShort(b *testing.B) { benchmarkIsCuid(b, "4y") }
+func BenchmarkIsCuidInvalidMax(b *testing.B)   { benchmarkIsCuid(b, "yi7rqj1trke4ynzfqcx3nxnq0tgu6v5Z") }
+
+func BenchmarkIsCuidWithBounds(b *testing.B) {
+	var valid bool
+
+	for n := 0; n < b.N; n++ {
+		valid = IsCuidWithBounds("yi7rqj1trke4ynzfqcx3nxnq0tgu6v5z", 1, 32)
+	}
+
+	isCuidResult = valid
+}
+
+func BenchmarkCuidChecker(b *testing.B) {
+	var valid bool
+
+	check := NewCuidChecker(1, 32)
+	for n := 0; n < b.N; n++ {
+		valid = check("yi7rqj1trke4ynzfqcx3nxnq0tgu6v5z")
+	}
+
+	isCuidResult = valid
+}
+
+// Adversarial inputs, which should take time linear in their length
+func BenchmarkIsCuidLowercase1KB(b *testing.B) { benchmarkIsCuid(b, strings.Repeat("a", 1<<10)) }
+func BenchmarkIsCuidLowercase1MB(b *testing.B) { benchmarkIsCuid(b, strings.Repeat("a", 1<<20)) }
+func BenchmarkIsCuidUppercase1MB(b *testing.B) { benchmarkIsCuid(b, strings.Repeat("A", 1<<20)) }
+func BenchmarkIsCuidUnicode1MB(b *testing.B)   { benchmarkIsCuid(b, strings.Repeat("é", 1<<19)) }
+func BenchmarkIsCuidTrailingInvalid1MB(b *testing.B) {
+	benchmarkIsCuid(b, strings.Repeat("a", 1<<20)+"!")
+}
+
+// Each parallel worker increments its own counter, so any slowdown of adjacent
+// counters relative to padded counters is due to false sharing
+func benchmarkAdjacentCounters(b *testing.B, counters []Counter) {
+	var nextWorker int64 = -1
+
+	b.RunParallel(func(pb *testing.PB) {
+		counter := counters[int(atomic.AddInt64(&nextWorker, 1))%len(counters)]
+		for pb.Next() {
+			counter.Increment()
+		}
+	})
+}
+
+func BenchmarkAdjacentSessionCounters(b *testing.B) {
+	sessionCounters := make([]SessionCounter, runtime.GOMAXPROCS(0))
+	counters := []Counter{}
+	for index := range sessionCounters {
+		counters = append(counters, &sessionCounters[index])
+	}
+	benchmarkAdjacentCounters(b, counters)
+}
+
+func BenchmarkAdjacentPaddedSessionCounters(b *testing.B) {
+	paddedCounters := make([]PaddedSessionCounter, runtime.GOMAXPROCS(0))
+	counters := []Counter{}
+	for index := range paddedCounters {
+		counters = append(counters, &paddedCounters[index])
+	}
+	benchmarkAdjacentCounters(b, counters)
+}
+
+func BenchmarkInit(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		if _, err := Init(); err != nil {
+			log.Fatalln("Error: Could not initialise Cuid2 generator")
+		}
+	}
+}
+
+func BenchmarkInitWithSharedFingerprint(b *testing.B) {
+	for n := 0; n < b.N; n++ {
+		if _, err := Init(WithSharedFingerprint()); err != nil {
+			log.Fatalln("Error: Could not initialise Cuid2 generator")
+		}
+	}
+}
+
+func BenchmarkCreateFingerprint(b *testing.B) {
+	var fingerprint string
+
+	for n := 0; n < b.N; n++ {
+		fingerprint = createFingerprint(rand.Float64, getEnvironmentKeyString())
+	}
+
+	result = fingerprint
+}
+
+func benchmarkCreateEntropy(b *testing.B, length int) {
+	var entropy string
+
+	b.ReportAllocs()
+	for n := 0; n < b.N; n++ {
+		entropy = createEntropy(length, rand.Float64)
+	}
+
+	result = entropy
+}
+
+func BenchmarkCreateEntropy8(b *testing.B)  { benchmarkCreateEntropy(b, 8) }
+func BenchmarkCreateEntropy32(b *testing.B) { benchmarkCreateEntropy(b, 32) }
@@ -0,0 +1,122 @@
+package cuid2
+
+import (
+	"fmt"
+	"hash/fnv"
+	"math"
+	"sync"
+)
+
+// A Cuid generator that records each emitted Cuid in a fixed-size bloom
+// filter and reports any Cuid that may have been emitted before, e.g. to
+// monitor for collisions in production at bounded memory.
+//
+// A bloom filter never misses a re-emitted Cuid, but may report a Cuid that
+// was never emitted before. The false positive rate holds until the configured
+// capacity is reached and rises as more Cuids are recorded beyond it, so a
+// report is a suspected collision rather than proof of one.
+type BloomAuditGenerator struct {
+	mutex                sync.Mutex
+	generator            *Generator
+	bits                 []uint64
+	bitCount             uint64
+	hashCount            int
+	onSuspectedCollision func(cuid string)
+}
+
+// Creates a new bloom audit generator sized for the given number of Cuids at
+// the given false positive rate, applying the given options to the underlying
+// generator.
+//
+// The callback is invoked synchronously with each Cuid that may have been
+// emitted before, so it should be fast.
+func NewBloomAuditGenerator(
+	capacity int,
+	falsePositiveRate float64,
+	onSuspectedCollision func(cuid string),
+	options ...Option,
+) (*BloomAuditGenerator, error) {
+	if capacity <= 0 {
+		return nil, fmt.Errorf("Error: the bloom filter capacity must be greater than 0")
+	}
+
+	if falsePositiveRate <= 0 || falsePositiveRate >= 1 {
+		return nil, fmt.Errorf("Error: the false positive rate must be between 0 and 1")
+	}
+
+	if onSuspectedCollision == nil {
+		return nil, fmt.Errorf("Error: the provided suspected collision callback must not be nil")
+	}
+
+	generator, err := NewGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	// Optimal number of bits and hash functions for the capacity and rate
+	bitCount := uint64(math.Ceil(-float64(capacity) * math.Log(falsePositiveRate) / (math.Ln2 * math.Ln2)))
+	hashCount := int(math.Max(1, math.Round(float64(bitCount)/float64(capacity)*math.Ln2)))
+
+	return &BloomAuditGenerator{
+		generator:            generator,
+		bits:                 make([]uint64, (bitCount+63)/64),
+		bitCount:             bitCount,
+		hashCount:            hashCount,
+		onSuspectedCollision: onSuspectedCollision,
+	}, nil
+}
+
+// Generates a new Cuid, invoking the callback if it may have been emitted
+// before
+func (bg *BloomAuditGenerator) Generate() string {
+	cuid := bg.generator.Generate()
+	if cuid == "" {
+		return cuid
+	}
+
+	if bg.record(cuid) {
+		bg.onSuspectedCollision(cuid)
+	}
+
+	return cuid
+}
+
+// Stops any background work started by the underlying generator
+func (bg *BloomAuditGenerator) Close() {
+	bg.generator.Close()
+}
+
+// Records the Cuid in the bloom filter, returning whether it may have been
+// recorded before
+func (bg *BloomAuditGenerator) record(cuid string) bool {
+	first, second := bloomHashes(cuid)
+
+	bg.mutex.Lock()
+	defer bg.mutex.Unlock()
+
+	seen := true
+	for index := 0; index < bg.hashCount; index++ {
+		// Derives each hash function from two base hashes, by double hashing
+		bit := (first + uint64(index)*second) % bg.bitCount
+		word, mask := bit/64, uint64(1)<<(bit%64)
+
+		if bg.bits[word]&mask == 0 {
+			seen = false
+			bg.bits[word] |= mask
+		}
+	}
+
+	return seen
+}
+
+func bloomHashes(cuid string) (uint64, uint64) {
+	hasher := fnv.New64a()
+	hasher.Write([]byte(cuid))
+	first := hasher.Sum64()
+
+	hasher.Write([]byte{0})
+	// Odd, so that successive hashes do not repeat early
+	second := hasher.Sum64() | 1
+
+	return first, second
+}
@@ -0,0 +1,62 @@
+package cuid2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestBloomAuditGenerator(t *testing.T) {
+	suspected := []string{}
+	onSuspectedCollision := func(cuid string) {
+		suspected = append(suspected, cuid)
+	}
+
+	auditGenerator, err := NewBloomAuditGenerator(10000, 0.001, onSuspectedCollision)
+	if err != nil {
+		t.Fatalf("Expected to initialize bloom audit generator but received error = %v", err.Error())
+	}
+	defer auditGenerator.Close()
+
+	for i := 0; i < 10000; i++ {
+		if cuid := auditGenerator.Generate(); !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid, but got %v", cuid)
+		}
+	}
+
+	// Roughly 10 false positives are expected at a rate of 0.001
+	if len(suspected) > 50 {
+		t.Fatalf("Expected few suspected collisions among unique Cuids, but got %v", len(suspected))
+	}
+
+	suspected = []string{}
+	repeating, _ := NewBloomAuditGenerator(
+		100,
+		0.01,
+		onSuspectedCollision,
+		WithSessionCounter(plainCounter{}),
+		WithRandomFuncSamples(func() float64 { return 0.5 }, 1),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(0) }),
+	)
+
+	first := repeating.Generate()
+	if len(suspected) != 0 {
+		t.Fatalf("Expected the first Cuid to not be reported, but got %v", suspected)
+	}
+
+	repeating.Generate()
+	if len(suspected) != 1 || suspected[0] != first {
+		t.Fatalf("Expected the re-emitted Cuid (%v) to be reported, but got %v", first, suspected)
+	}
+
+	if _, err := NewBloomAuditGenerator(0, 0.01, onSuspectedCollision); err == nil {
+		t.Fatalf("Expected to receive an error for a capacity of 0, but got nothing")
+	}
+
+	if _, err := NewBloomAuditGenerator(100, 1, onSuspectedCollision); err == nil {
+		t.Fatalf("Expected to receive an error for a false positive rate of 1, but got nothing")
+	}
+
+	if _, err := NewBloomAuditGenerator(100, 0.01, nil); err == nil {
+		t.Fatalf("Expected to receive an error for a nil callback, but got nothing")
+	}
+}
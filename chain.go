@@ -0,0 +1,54 @@
+package cuid2
+
+import (
+	"strings"
+)
+
+// Number of characters reserved at the end of a Cuid for the chain link
+const chainLinkLength = 4
+
+// Chains each Cuid to the previously generated one, folding the previous Cuid
+// into the hash input and embedding a short link derived from it at the end of
+// the Cuid, so that VerifyChain can later detect a Cuid in the sequence that
+// was altered, removed or reordered.
+//
+// The chain is only verifiable if the Cuids are stored in the order they were
+// generated, and the first Cuid of a generator is linked to an empty previous
+// Cuid. Generation is serialized to keep the chain intact, so this option is
+// not suited to generators under heavy concurrent use.
+//
+// This is opt-in, as the link replaces the last 4 random characters of each
+// Cuid, reducing its entropy. The link is not keyed, so it detects accidental
+// or naive tampering, but anyone can recompute a forged chain. Cannot be
+// combined with non-base36 encodings, fingerprint marker, recoverable
+// sequence, checksum, format header or generation timeout options.
+func WithChainedGeneration() Option {
+	return func(config *Config) error {
+		config.ChainedGeneration = true
+		return nil
+	}
+}
+
+// Checks whether each Cuid in the sequence carries the link of the Cuid before
+// it, as generated with WithChainedGeneration.
+//
+// The first Cuid is taken as the anchor of the sequence, so its own link is not
+// checked. With 4 base36 characters, roughly 1 in 1.7 million altered Cuids
+// will still carry a matching link.
+func VerifyChain(ids []string) bool {
+	for index, id := range ids {
+		if !IsCuid(id) || len(id) <= chainLinkLength {
+			return false
+		}
+
+		if index > 0 && !strings.HasSuffix(id, createChainLink(ids[index-1])) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func createChainLink(previousID string) string {
+	return Hash("chain" + previousID)[1 : chainLinkLength+1]
+}
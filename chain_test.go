@@ -0,0 +1,49 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestChainedGeneration(t *testing.T) {
+	generator, err := NewGenerator(WithChainedGeneration())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuids := []string{}
+	for len(cuids) < 10 {
+		cuid := generator.Generate()
+		if len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid chained Cuid, but got %v", cuid)
+		}
+		cuids = append(cuids, cuid)
+	}
+
+	if !VerifyChain(cuids) {
+		t.Fatalf("Expected the chain (%v) to be valid", cuids)
+	}
+
+	if !VerifyChain(cuids[3:]) {
+		t.Fatalf("Expected a chain starting midway through the sequence to be valid")
+	}
+
+	tampered := append([]string{}, cuids...)
+	tampered[4] = generator.Generate()
+	if VerifyChain(tampered) {
+		t.Fatalf("Expected a chain with a replaced Cuid to be invalid")
+	}
+
+	reordered := append([]string{}, cuids...)
+	reordered[2], reordered[3] = reordered[3], reordered[2]
+	if VerifyChain(reordered) {
+		t.Fatalf("Expected a reordered chain to be invalid")
+	}
+
+	if VerifyChain(append(cuids[:5:5], cuids[6:]...)) {
+		t.Fatalf("Expected a chain with a removed Cuid to be invalid")
+	}
+
+	if _, err := Init(WithChainedGeneration(), WithChecksumLength(1)); err == nil {
+		t.Fatalf("Expected to receive an error when combining chained generation with checksums, but got nothing")
+	}
+}
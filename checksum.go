@@ -0,0 +1,54 @@
+package cuid2
+
+import (
+	"fmt"
+	"hash/crc32"
+	"math"
+	"strconv"
+	"strings"
+)
+
+const (
+	MinChecksumLength int = 1
+	MaxChecksumLength int = 2
+)
+
+// Appends a base36 checksum of the given width (1 or 2 characters) to each
+// Cuid, so that transcription errors can be detected with VerifyChecksum.
+//
+// The checksum replaces the last characters of each Cuid, so a Cuid keeps its
+// configured length but carries less entropy. A 1 character checksum misses
+// roughly 1 in 36 errors, while a 2 character checksum misses roughly 1 in
+// 1296. This option cannot be combined with non-base36 encodings, fingerprint
+// marker or recoverable sequence options.
+func WithChecksumLength(length int) Option {
+	return func(config *Config) error {
+		if length < MinChecksumLength || length > MaxChecksumLength {
+			return fmt.Errorf("Error: the checksum length must be between %v and %v", MinChecksumLength, MaxChecksumLength)
+		}
+		config.ChecksumLength = length
+		return nil
+	}
+}
+
+// Checks whether a Cuid ends with a valid checksum of the given width
+func VerifyChecksum(cuid string, length int) bool {
+	if length < MinChecksumLength || length > MaxChecksumLength {
+		return false
+	}
+
+	if !IsCuid(cuid) || len(cuid) < MinIdLength+length {
+		return false
+	}
+
+	body := cuid[:len(cuid)-length]
+
+	return cuid[len(body):] == createChecksum(body, length)
+}
+
+// Computes a fixed-width base36 checksum from the CRC-32 of the body
+func createChecksum(body string, length int) string {
+	modulus := uint32(math.Pow(36, float64(length)))
+	checksum := strconv.FormatUint(uint64(crc32.ChecksumIEEE([]byte(body))%modulus), 36)
+	return strings.Repeat("0", length-len(checksum)) + checksum
+}
@@ -0,0 +1,82 @@
+package cuid2
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestChecksum(t *testing.T) {
+	for _, checksumLength := range []int{1, 2} {
+		generator, err := NewGenerator(WithChecksumLength(checksumLength))
+		if err != nil {
+			t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+		}
+
+		cuid := generator.Generate()
+		if len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with a checksum, but got %v", cuid)
+		}
+
+		if !VerifyChecksum(cuid, checksumLength) {
+			t.Fatalf("Expected Cuid (%v) to have a valid checksum of length %v", cuid, checksumLength)
+		}
+	}
+
+	if _, err := Init(WithChecksumLength(3)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithChecksumLength(3)), but got nothing")
+	}
+
+	if _, err := Init(WithChecksumLength(1), WithRecoverableSequence()); err == nil {
+		t.Fatalf("Expected to receive an error when combining checksums with a recoverable sequence, but got nothing")
+	}
+}
+
+func TestChecksumDetectionRates(t *testing.T) {
+	random := rand.New(rand.NewSource(42))
+	samples := 2000
+
+	// Replaces the character at a random position in the body with a different
+	// base36 character
+	corrupt := func(cuid []byte, bodyLength int) {
+		position := 1 + random.Intn(bodyLength-1)
+		replacement := cuid[position]
+		for replacement == cuid[position] {
+			replacement = Base36Digits[random.Intn(36)]
+		}
+		cuid[position] = replacement
+	}
+
+	testCases := []struct {
+		checksumLength int
+		errors         int
+		minRate        float64
+	}{
+		{1, 1, 0.95},
+		{1, 2, 0.95},
+		{2, 1, 0.99},
+		{2, 2, 0.99},
+	}
+
+	for _, testCase := range testCases {
+		generator, _ := NewGenerator(WithChecksumLength(testCase.checksumLength))
+		detected := 0
+
+		for i := 0; i < samples; i++ {
+			cuid := []byte(generator.Generate())
+			for e := 0; e < testCase.errors; e++ {
+				corrupt(cuid, len(cuid)-testCase.checksumLength)
+			}
+			if !VerifyChecksum(string(cuid), testCase.checksumLength) {
+				detected++
+			}
+		}
+
+		rate := float64(detected) / float64(samples)
+		if rate < testCase.minRate {
+			t.Fatalf(
+				"Expected a %v character checksum to detect at least %v of %v character errors, but got %v",
+				testCase.checksumLength, testCase.minRate, testCase.errors, rate,
+			)
+		}
+	}
+}
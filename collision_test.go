@@ -5,7 +5,6 @@ import (
 	"log"
 	"math"
 	"math/big"
-	"strconv"
 	"sync"
 	"testing"
 )
@@ -87,7 +86,11 @@ func NewIdPool(max int) func() *IdPool {
 
 		for element := range set {
 			ids = append(ids, element)
-			numbers = append(numbers, *idToBigInt(element[1:]))
+			number, err := BodyValue(element)
+			if err != nil {
+				log.Fatalf("Expected Cuid (%v) to have a numeric body, but got error = %v", element, err)
+			}
+			numbers = append(numbers, *number)
 		}
 
 		return &IdPool{
@@ -98,15 +101,6 @@ func NewIdPool(max int) func() *IdPool {
 	}
 }
 
-func idToBigInt(id string) *big.Int {
-	bigInt := new(big.Int)
-	for _, char := range id {
-		base36Rune, _ := strconv.ParseInt(string(char), 36, 64)
-		bigInt.Add(big.NewInt(base36Rune), bigInt.Mul(bigInt, big.NewInt(36)))
-	}
-	return bigInt
-}
-
 func buildHistogram(numbers []big.Int, bucketCount int) []float64 {
 	log.Println("Building histogram...")
 
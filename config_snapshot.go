@@ -0,0 +1,298 @@
+package cuid2
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+)
+
+// An immutable snapshot of a generator's resolved config, excluding functions
+// such as the random function and readers such as the entropy reader
+type ConfigSnapshot struct {
+	Length                           int
+	Fingerprint                      string
+	CounterType                      string
+	TimeResolution                   time.Duration
+	TimeOffset                       time.Duration
+	FingerprintEnvironmentKeys       []string
+	EnvironmentFingerprintDisallowed bool
+	FingerprintRotationInterval      time.Duration
+	RejectedWords                    []string
+	ForbiddenIDs                     []string
+	DistributionTracking             bool
+	SortableDescending               bool
+	DateBucketUnit                   time.Duration
+	EntropyPoolSize                  int
+	EntropyLength                    int
+	MinEntropyLength                 int
+	CounterWidth                     int
+	BalancedComponents               bool
+	FingerprintMarker                bool
+	RecoverableSequence              bool
+	ChainedGeneration                bool
+	Encoding                         Encoding
+	FingerprintInfluence             int
+	ChecksumLength                   int
+	ShardHint                        int
+	HashInputOrder                   []Component
+	NormalizeFingerprint             bool
+	ShardId                          uint16
+	HasShardId                       bool
+	ReseedInterval                   time.Duration
+	EmbeddedLength                   bool
+	FormatHeader                     bool
+	DeterministicFirstLetter         bool
+	FixedFirstLetter                 bool
+	BuildVersion                     string
+	ProcessMemoryEntropy             bool
+	NoPanic                          bool
+	GenerationTimeout                time.Duration
+}
+
+// Returns a snapshot of the generator's resolved config, e.g. for logging or
+// comparing generator setups across nodes
+func (g *Generator) Config() ConfigSnapshot {
+	config := g.config
+
+	var forbiddenIDs []string
+	for id := range config.ForbiddenIDs {
+		forbiddenIDs = append(forbiddenIDs, id)
+	}
+	sort.Strings(forbiddenIDs)
+
+	return ConfigSnapshot{
+		Length:                           config.Length,
+		Fingerprint:                      g.fingerprint.Load().(string),
+		CounterType:                      fmt.Sprintf("%T", config.SessionCounter),
+		TimeResolution:                   config.TimeResolution,
+		TimeOffset:                       config.TimeOffset,
+		FingerprintEnvironmentKeys:       append([]string(nil), config.FingerprintEnvironmentKeys...),
+		EnvironmentFingerprintDisallowed: config.EnvironmentFingerprintDisallowed,
+		FingerprintRotationInterval:      config.FingerprintRotationInterval,
+		RejectedWords:                    append([]string(nil), config.RejectedWords...),
+		ForbiddenIDs:                     forbiddenIDs,
+		DistributionTracking:             config.DistributionTracking,
+		SortableDescending:               config.SortableDescending,
+		DateBucketUnit:                   config.DateBucketUnit,
+		EntropyPoolSize:                  config.EntropyPoolSize,
+		EntropyLength:                    config.EntropyLength,
+		MinEntropyLength:                 config.MinEntropyLength,
+		CounterWidth:                     config.CounterWidth,
+		BalancedComponents:               config.BalancedComponents,
+		FingerprintMarker:                config.FingerprintMarker,
+		RecoverableSequence:              config.RecoverableSequence,
+		ChainedGeneration:                config.ChainedGeneration,
+		Encoding:                         config.Encoding,
+		FingerprintInfluence:             config.FingerprintInfluence,
+		ChecksumLength:                   config.ChecksumLength,
+		ShardHint:                        config.ShardHint,
+		HashInputOrder:                   append([]Component(nil), config.HashInputOrder...),
+		NormalizeFingerprint:             config.NormalizeFingerprint,
+		ShardId:                          config.ShardId,
+		HasShardId:                       config.HasShardId,
+		ReseedInterval:                   config.ReseedInterval,
+		EmbeddedLength:                   config.EmbeddedLength,
+		FormatHeader:                     config.FormatHeader,
+		DeterministicFirstLetter:         config.DeterministicFirstLetter,
+		FixedFirstLetter:                 config.FixedFirstLetter,
+		BuildVersion:                     config.BuildVersion,
+		ProcessMemoryEntropy:             config.ProcessMemoryEntropy,
+		NoPanic:                          config.NoPanic,
+		GenerationTimeout:                config.GenerationTimeout,
+	}
+}
+
+// Encodes the snapshot as JSON, e.g. to persist a generator's identity to disk
+// and restore it after a restart with WithConfigSnapshot
+func (s ConfigSnapshot) MarshalText() ([]byte, error) {
+	// Marshals a distinct type, so that json.Marshal does not recurse into
+	// MarshalText
+	type snapshot ConfigSnapshot
+	return json.Marshal(snapshot(s))
+}
+
+// Decodes a snapshot encoded with MarshalText
+func (s *ConfigSnapshot) UnmarshalText(text []byte) error {
+	type snapshot ConfigSnapshot
+
+	var decoded snapshot
+	if err := json.Unmarshal(text, &decoded); err != nil {
+		return fmt.Errorf("Error: cannot unmarshal the config snapshot: %w", err)
+	}
+
+	*s = ConfigSnapshot(decoded)
+
+	return nil
+}
+
+// Restores the length, fingerprint and other settings captured by a config
+// snapshot, e.g. one saved with MarshalText before a restart, so that the new
+// generator keeps the identity of the old one.
+//
+// The snapshot holds the resolved fingerprint, into which the shard id, build
+// version, process entropy and normalization it records are not folded again,
+// so options such as WithShardId or WithBuildVersion should not be passed
+// again. The counter type and random function are not restored, use
+// Generator.ExportCounter and Generator.ImportCounter to carry over the session
+// count. The fingerprint environment keys are not restored either, as the
+// fingerprint already reflects them.
+//
+// Snapshots are validated like the equivalent options, e.g. the length must be
+// within MinIdLength and MaxIdLength. Zero values, such as a missing length,
+// fingerprint or time resolution, keep the defaults.
+func WithConfigSnapshot(snapshot ConfigSnapshot) Option {
+	return func(config *Config) error {
+		options := []Option{WithRejectWords(snapshot.RejectedWords)}
+
+		if snapshot.Length != 0 {
+			options = append(options, WithLength(snapshot.Length))
+		}
+
+		if snapshot.Fingerprint != "" {
+			options = append(options, WithFingerprint(snapshot.Fingerprint))
+		}
+
+		if snapshot.TimeResolution != 0 {
+			options = append(options, WithTimeResolution(snapshot.TimeResolution))
+		}
+
+		if snapshot.TimeOffset != 0 {
+			options = append(options, WithTimeOffset(snapshot.TimeOffset))
+		}
+
+		if snapshot.FingerprintRotationInterval > 0 {
+			options = append(options, WithFingerprintRotation(snapshot.FingerprintRotationInterval))
+		}
+
+		if len(snapshot.ForbiddenIDs) > 0 {
+			forbiddenIDs := make(map[string]struct{}, len(snapshot.ForbiddenIDs))
+			for _, id := range snapshot.ForbiddenIDs {
+				forbiddenIDs[id] = struct{}{}
+			}
+			options = append(options, WithForbiddenIDs(forbiddenIDs))
+		}
+
+		if snapshot.DistributionTracking {
+			options = append(options, WithDistributionTracking())
+		}
+
+		if snapshot.SortableDescending {
+			options = append(options, WithSortableDescending())
+		}
+
+		if snapshot.DateBucketUnit != 0 {
+			options = append(options, WithDateBucketPrefix(snapshot.DateBucketUnit))
+		}
+
+		if snapshot.EntropyPoolSize != 0 {
+			options = append(options, WithEntropyPool(snapshot.EntropyPoolSize))
+		}
+
+		if snapshot.EntropyLength != 0 {
+			options = append(options, WithEntropyLength(snapshot.EntropyLength))
+		}
+
+		if snapshot.MinEntropyLength != 0 {
+			options = append(options, WithMinEntropyLength(snapshot.MinEntropyLength))
+		}
+
+		if snapshot.CounterWidth != 0 {
+			options = append(options, WithCounterWidth(snapshot.CounterWidth))
+		}
+
+		if snapshot.BalancedComponents {
+			options = append(options, WithBalancedComponents())
+		}
+
+		if snapshot.FingerprintMarker {
+			options = append(options, WithFingerprintMarker())
+		}
+
+		if snapshot.RecoverableSequence {
+			options = append(options, WithRecoverableSequence())
+		}
+
+		if snapshot.ChainedGeneration {
+			options = append(options, WithChainedGeneration())
+		}
+
+		if snapshot.Encoding != Base36 {
+			options = append(options, WithEncoding(snapshot.Encoding))
+		}
+
+		if snapshot.FingerprintInfluence != 0 {
+			options = append(options, WithFingerprintInfluence(snapshot.FingerprintInfluence))
+		}
+
+		if snapshot.ChecksumLength != 0 {
+			options = append(options, WithChecksumLength(snapshot.ChecksumLength))
+		}
+
+		if snapshot.ShardHint != 0 {
+			options = append(options, WithShardHint(snapshot.ShardHint))
+		}
+
+		if snapshot.HashInputOrder != nil {
+			options = append(options, WithHashInputOrder(snapshot.HashInputOrder))
+		}
+
+		if snapshot.ReseedInterval != 0 {
+			options = append(options, WithReseedInterval(snapshot.ReseedInterval))
+		}
+
+		if snapshot.EmbeddedLength {
+			options = append(options, WithEmbeddedLength())
+		}
+
+		if snapshot.FormatHeader {
+			options = append(options, WithFormatHeader())
+		}
+
+		if snapshot.DeterministicFirstLetter {
+			options = append(options, WithDeterministicFirstLetter())
+		}
+
+		if snapshot.FixedFirstLetter {
+			options = append(options, WithoutFirstLetterRandomness())
+		}
+
+		if snapshot.NoPanic {
+			options = append(options, WithNoPanic())
+		}
+
+		if snapshot.GenerationTimeout != 0 {
+			options = append(options, WithGenerationTimeout(snapshot.GenerationTimeout))
+		}
+
+		if snapshot.NormalizeFingerprint {
+			options = append(options, WithNormalizedFingerprint())
+		}
+
+		if snapshot.HasShardId {
+			options = append(options, WithShardId(snapshot.ShardId))
+		}
+
+		if snapshot.BuildVersion != "" {
+			options = append(options, WithBuildVersion(snapshot.BuildVersion))
+		}
+
+		if snapshot.ProcessMemoryEntropy {
+			options = append(options, WithProcessMemoryEntropy())
+		}
+
+		for _, option := range options {
+			if err := option(config); err != nil {
+				return err
+			}
+		}
+
+		if snapshot.EnvironmentFingerprintDisallowed {
+			config.EnvironmentFingerprintDisallowed = true
+		}
+
+		config.restoredFingerprint = snapshot.Fingerprint
+
+		return nil
+	}
+}
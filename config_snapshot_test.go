@@ -0,0 +1,136 @@
+package cuid2
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestConfigSnapshotRoundTrip(t *testing.T) {
+	generator, err := NewGenerator(
+		WithLength(16),
+		WithShardId(7),
+		WithTimeResolution(time.Second),
+		WithRejectWords([]string{"abc"}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	text, err := generator.Config().MarshalText()
+	if err != nil {
+		t.Fatalf("Expected to marshal the config snapshot but received error = %v", err.Error())
+	}
+
+	var snapshot ConfigSnapshot
+	if err := snapshot.UnmarshalText(text); err != nil {
+		t.Fatalf("Expected to unmarshal the config snapshot but received error = %v", err.Error())
+	}
+
+	if !reflect.DeepEqual(snapshot, generator.Config()) {
+		t.Fatalf("Expected the unmarshalled snapshot to be %+v, but got %+v", generator.Config(), snapshot)
+	}
+
+	restored, err := NewGenerator(WithConfigSnapshot(snapshot))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator from a snapshot but received error = %v", err.Error())
+	}
+
+	if !reflect.DeepEqual(restored.Config(), generator.Config()) {
+		t.Fatalf("Expected the restored config to be %+v, but got %+v", generator.Config(), restored.Config())
+	}
+
+	if cuid := restored.Generate(); len(cuid) != 16 || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with a length of 16, but got %v", cuid)
+	}
+
+	if err := snapshot.UnmarshalText([]byte("not json")); err == nil {
+		t.Fatalf("Expected to receive an error when unmarshalling invalid text, but got nothing")
+	}
+
+	if _, err := Init(WithConfigSnapshot(ConfigSnapshot{Length: MaxIdLength + 1})); err == nil {
+		t.Fatalf("Expected to receive an error for a snapshot with a length above MaxIdLength, but got nothing")
+	}
+
+	if _, err := Init(WithConfigSnapshot(ConfigSnapshot{TimeResolution: -time.Second})); err == nil {
+		t.Fatalf("Expected to receive an error for a snapshot with a negative time resolution, but got nothing")
+	}
+}
+
+func TestFullConfigSnapshotRoundTrip(t *testing.T) {
+	generator, err := NewGenerator(
+		WithLength(20),
+		WithFingerprint("Node-1 "),
+		WithNormalizedFingerprint(),
+		WithShardId(7),
+		WithBuildVersion("1.2.3"),
+		WithTimeOffset(5*time.Second),
+		WithForbiddenIDs(map[string]struct{}{"b": {}, "a": {}}),
+		WithChecksumLength(2),
+		WithEntropyLength(24),
+		WithCounterWidth(3),
+		WithBalancedComponents(),
+		WithHashInputOrder([]Component{FingerprintComponent, CounterComponent, SaltComponent, TimeComponent}),
+		WithFingerprintInfluence(8),
+		WithFormatHeader(),
+		WithNoPanic(),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	text, err := generator.Config().MarshalText()
+	if err != nil {
+		t.Fatalf("Expected to marshal the config snapshot but received error = %v", err.Error())
+	}
+
+	var snapshot ConfigSnapshot
+	if err := snapshot.UnmarshalText(text); err != nil {
+		t.Fatalf("Expected to unmarshal the config snapshot but received error = %v", err.Error())
+	}
+
+	restored, err := NewGenerator(WithConfigSnapshot(snapshot))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator from a snapshot but received error = %v", err.Error())
+	}
+
+	if !reflect.DeepEqual(restored.Config(), generator.Config()) {
+		t.Fatalf("Expected the restored config to be %+v, but got %+v", generator.Config(), restored.Config())
+	}
+
+	base36, _ := NewGenerator(WithFingerprint("node-1"))
+	crockford, _ := NewGenerator(WithFingerprint("node-1"), WithEncoding(CrockfordBase32))
+	if reflect.DeepEqual(base36.Config(), crockford.Config()) {
+		t.Fatalf("Expected generators with different encodings to have different snapshots")
+	}
+}
+
+func TestZeroConfigSnapshotRoundTrip(t *testing.T) {
+	text, err := ConfigSnapshot{}.MarshalText()
+	if err != nil {
+		t.Fatalf("Expected to marshal the config snapshot but received error = %v", err.Error())
+	}
+
+	var snapshot ConfigSnapshot
+	if err := snapshot.UnmarshalText(text); err != nil {
+		t.Fatalf("Expected to unmarshal the config snapshot but received error = %v", err.Error())
+	}
+
+	if !reflect.DeepEqual(snapshot, ConfigSnapshot{}) {
+		t.Fatalf("Expected the unmarshalled snapshot to be zero-valued, but got %+v", snapshot)
+	}
+
+	generator, err := NewGenerator(WithConfigSnapshot(snapshot))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator from a zero-valued snapshot but received error = %v", err.Error())
+	}
+
+	restored := generator.Config()
+	if restored.Length != DefaultIdLength || restored.TimeResolution != time.Millisecond || restored.Fingerprint == "" {
+		t.Fatalf("Expected a zero-valued snapshot to keep the defaults, but got %+v", restored)
+	}
+
+	if cuid := generator.Generate(); len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with the default length, but got %v", cuid)
+	}
+}
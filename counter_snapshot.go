@@ -0,0 +1,94 @@
+package cuid2
+
+import (
+	"fmt"
+	"sync/atomic"
+)
+
+// A counter whose state can be exported and imported, e.g. to hand it over
+// from an active node to a standby node on failover
+type SnapshotCounter interface {
+	Counter
+
+	// Returns the most recently returned count
+	Load() int64
+
+	// Replaces the current count, so that the next call to Increment continues
+	// from it
+	Store(count int64)
+}
+
+func (sc *SessionCounter) Load() int64 {
+	return atomic.LoadInt64(&sc.value)
+}
+
+func (sc *SessionCounter) Store(count int64) {
+	atomic.StoreInt64(&sc.value, count)
+}
+
+func (psc *PaddedSessionCounter) Load() int64 {
+	return atomic.LoadInt64(&psc.value)
+}
+
+func (psc *PaddedSessionCounter) Store(count int64) {
+	atomic.StoreInt64(&psc.value, count)
+}
+
+// Returns the most recently returned value of the clock counter
+func (cc *ClockCounter) Load() int64 {
+	return atomic.LoadInt64(&cc.last)
+}
+
+// Replaces the most recently returned value, so that subsequent values exceed
+// it even if the clock reads earlier
+func (cc *ClockCounter) Store(count int64) {
+	atomic.StoreInt64(&cc.last, count)
+}
+
+// Exports the current state of the generator's session counter, e.g. to import
+// it into a standby generator with ImportCounter on failover.
+//
+// Returns an error if the counter does not implement SnapshotCounter, which
+// all counters provided by the package do.
+func (g *Generator) ExportCounter() (int64, error) {
+	counter, ok := g.config.SessionCounter.(SnapshotCounter)
+	if !ok {
+		return 0, fmt.Errorf("Error: the session counter (%T) does not support exporting its state", g.config.SessionCounter)
+	}
+
+	return counter.Load(), nil
+}
+
+// Imports a session counter state exported with ExportCounter, so that the
+// generator continues counting from it rather than reusing counts already
+// issued by the exporting generator.
+//
+// Coordinating counters reduces the correlation between Cuids generated by the
+// two nodes, but does not on its own prevent collisions, which remain guarded
+// by the fingerprint, salt and timestamp.
+func (g *Generator) ImportCounter(count int64) error {
+	counter, ok := g.config.SessionCounter.(SnapshotCounter)
+	if !ok {
+		return fmt.Errorf("Error: the session counter (%T) does not support importing its state", g.config.SessionCounter)
+	}
+
+	counter.Store(count)
+
+	return nil
+}
+
+// Returns the number of counts left before the session counter crosses
+// MaxSessionCount, e.g. to alert before a long-running generator reaches the
+// ceiling. The counter is read without being incremented.
+//
+// The headroom is negative once the ceiling has been crossed, which is always
+// the case for a ClockCounter. Returns an error if the counter does not
+// implement SnapshotCounter.
+func (g *Generator) CounterHeadroom() (int64, error) {
+	count, err := g.ExportCounter()
+	if err != nil {
+		return 0, err
+	}
+
+	return MaxSessionCount - count, nil
+}
@@ -0,0 +1,62 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+type plainCounter struct{}
+
+func (plainCounter) Increment() int64 { return 0 }
+
+func TestExportAndImportCounter(t *testing.T) {
+	for _, counterType := range []CounterType{AtomicCounterType, PaddedCounterType, ClockCounterType} {
+		active, _ := NewGenerator(WithCounterType(counterType))
+		standby, _ := NewGenerator(WithCounterType(counterType))
+
+		active.Generate()
+		exported, err := active.ExportCounter()
+		if err != nil {
+			t.Fatalf("Expected to export counter but received error = %v", err.Error())
+		}
+
+		if err := standby.ImportCounter(exported); err != nil {
+			t.Fatalf("Expected to import counter but received error = %v", err.Error())
+		}
+
+		if next := standby.config.SessionCounter.Increment(); next <= exported {
+			t.Fatalf("Expected counter type %v to continue past %v after import, but got %v", counterType, exported, next)
+		}
+	}
+
+	generator, _ := NewGenerator(WithSessionCounter(plainCounter{}))
+	if _, err := generator.ExportCounter(); err == nil {
+		t.Fatalf("Expected to receive an error when exporting an unsupported counter, but got nothing")
+	}
+
+	if err := generator.ImportCounter(42); err == nil {
+		t.Fatalf("Expected to receive an error when importing into an unsupported counter, but got nothing")
+	}
+}
+
+func TestCounterHeadroom(t *testing.T) {
+	generator, _ := NewGenerator(WithSessionCounter(NewSessionCounter(MaxSessionCount - 10)))
+
+	headroom, err := generator.CounterHeadroom()
+	if err != nil {
+		t.Fatalf("Expected to get counter headroom but received error = %v", err.Error())
+	}
+
+	if headroom != 10 {
+		t.Fatalf("Expected counter headroom to be 10, but got %v", headroom)
+	}
+
+	generator.Generate()
+	if headroom, _ := generator.CounterHeadroom(); headroom != 9 {
+		t.Fatalf("Expected counter headroom to be 9 after generating, but got %v", headroom)
+	}
+
+	unsupported, _ := NewGenerator(WithSessionCounter(plainCounter{}))
+	if _, err := unsupported.CounterHeadroom(); err == nil {
+		t.Fatalf("Expected to receive an error for an unsupported counter, but got nothing")
+	}
+}
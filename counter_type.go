@@ -0,0 +1,48 @@
+package cuid2
+
+import (
+	"fmt"
+	"math"
+	"math/rand"
+)
+
+// One of the counter implementations provided by the package, selected with
+// WithCounterType
+type CounterType int
+
+const (
+	// A SessionCounter starting at a random count, the default
+	AtomicCounterType CounterType = iota
+
+	// A PaddedSessionCounter starting at a random count
+	PaddedCounterType
+
+	// A ClockCounter
+	ClockCounterType
+)
+
+// Selects one of the counter implementations provided by the package, as a
+// simpler alternative to constructing a counter for WithSessionCounter.
+//
+// Counters are created with the same defaults as the default generator, e.g.
+// a random initial count for atomic counters.
+func WithCounterType(counterType CounterType) Option {
+	return func(config *Config) error {
+		switch counterType {
+		case AtomicCounterType:
+			config.SessionCounter = NewSessionCounter(createInitialSessionCount())
+		case PaddedCounterType:
+			config.SessionCounter = NewPaddedSessionCounter(createInitialSessionCount())
+		case ClockCounterType:
+			config.SessionCounter = NewClockCounter()
+		default:
+			return fmt.Errorf("Error: unknown counter type (%v)", counterType)
+		}
+		return nil
+	}
+}
+
+// Returns a random initial count below MaxSessionCount
+func createInitialSessionCount() int64 {
+	return int64(math.Floor(rand.Float64() * float64(MaxSessionCount)))
+}
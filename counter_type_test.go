@@ -0,0 +1,32 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestCounterType(t *testing.T) {
+	testCases := map[CounterType]string{
+		AtomicCounterType: "*cuid2.SessionCounter",
+		PaddedCounterType: "*cuid2.PaddedSessionCounter",
+		ClockCounterType:  "*cuid2.ClockCounter",
+	}
+
+	for counterType, expected := range testCases {
+		generator, err := NewGenerator(WithCounterType(counterType))
+		if err != nil {
+			t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+		}
+
+		if actual := generator.Config().CounterType; actual != expected {
+			t.Fatalf("Expected counter type %v to select %v, but got %v", counterType, expected, actual)
+		}
+
+		if cuid := generator.Generate(); !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with counter type %v, but got %v", counterType, cuid)
+		}
+	}
+
+	if _, err := Init(WithCounterType(CounterType(42))); err == nil {
+		t.Fatalf("Expected to receive an error for an unknown counter type, but got nothing")
+	}
+}
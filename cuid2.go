@@ -1,20 +1,35 @@
 package cuid2
 
 import (
+	"context"
+	cryptorand "crypto/rand"
+	"errors"
 	"fmt"
+	"io"
+	"log"
 	"math"
 	"math/big"
 	"math/rand"
 	"os"
 	"regexp"
+	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"sync/atomic"
 	"time"
 
 	"golang.org/x/crypto/sha3"
 )
 
+const (
+	// Letters that a Cuid can start with
+	Alphabet = "abcdefghijklmnopqrstuvwxyz"
+
+	// Characters that can appear in the body of a Cuid
+	Base36Digits = "0123456789abcdefghijklmnopqrstuvwxyz"
+)
+
 const (
 	DefaultIdLength int = 24
 	MinIdLength     int = 2
@@ -22,8 +37,37 @@ const (
 
 	// ~22k hosts before 50% chance of initial counter collision
 	MaxSessionCount int64 = 476782367
+
+	// Size in bytes of the entropy pool buffer when an entropy reader is
+	// provided without an explicit pool size
+	DefaultEntropyPoolSize int = 256
+
+	// Maximum number of times a Cuid is regenerated when it contains a
+	// rejected word
+	MaxRejectedWordRetries int = 10
+
+	// Maximum number of times a Cuid is regenerated when it is a forbidden id
+	MaxForbiddenIDRetries int = 10
+
+	// Maximum number of times a Cuid is regenerated across a batch when it
+	// duplicates another Cuid in the batch
+	MaxUniqueBatchRetries int = 100
+
+	// Number of times a custom random function is sampled for validation by
+	// WithRandomFunc
+	DefaultRandomFuncSamples int = 16
+)
+
+var (
+	ErrGenerationTimeout         = errors.New("Error: generation exceeded the configured timeout")
+	ErrLengthExceedsHashCapacity = errors.New("Error: the length exceeds the number of characters the hash can encode")
 )
 
+// Maximum length of a Cuid that can be encoded from a SHA3-512 digest. This is
+// the number of base36 characters of 2^448, less the character dropped by
+// hash, so that only digests below 2^448 (a 2^-64 chance) fall short.
+var hashCapacity = len(new(big.Int).Lsh(big.NewInt(1), 448).Text(36)) - 1
+
 type Config struct {
 	// A custom function that can generate a floating-point value between 0 and 1
 	RandomFunc func() float64
@@ -38,12 +82,191 @@ type Config struct {
 	// A unique string that will be used by the Cuid generator to help prevent
 	// collisions when generating Cuids in a distributed system.
 	Fingerprint string
+
+	// A custom function that returns the current time, used to compute the
+	// timestamp component of each Cuid
+	TimeFunc func() time.Time
+
+	// Granularity of the timestamp component of each Cuid
+	TimeResolution time.Duration
+
+	// Correction added to the time of each Cuid
+	TimeOffset time.Duration
+
+	// Names of the environment variables used to derive the fingerprint, all
+	// environment variables are used when empty
+	FingerprintEnvironmentKeys []string
+
+	// Whether the default fingerprint is derived from randomness alone, without
+	// the environment variables
+	EnvironmentFingerprintDisallowed bool
+
+	// Interval at which the fingerprint is re-derived, disabled when zero
+	FingerprintRotationInterval time.Duration
+
+	// A function invoked once when the session counter crosses MaxSessionCount
+	CounterOverflowHandler func()
+
+	// Substrings that generated Cuids should not contain
+	RejectedWords []string
+
+	// Ids that generated Cuids must not be equal to
+	ForbiddenIDs map[string]struct{}
+
+	// Whether the first letters of generated Cuids are tallied
+	DistributionTracking bool
+
+	// Whether generated Cuids sort lexically in descending order of time
+	SortableDescending bool
+
+	// Size of the time buckets encoded at the start of each Cuid, disabled
+	// when zero
+	DateBucketUnit time.Duration
+
+	// Size in bytes of the buffer used to batch random draws for entropy,
+	// disabled when zero
+	EntropyPoolSize int
+
+	// Number of random characters in the salt that enters the hash input, the
+	// length of the Cuid is used when zero
+	EntropyLength int
+
+	// Minimum number of random characters in the salt, disabled when zero
+	MinEntropyLength int
+
+	// Fixed number of base36 characters of the session count that enter the
+	// hash input, the full count is used when zero
+	CounterWidth int
+
+	// Whether the timestamp and session count are zero-padded to fixed widths
+	// in the hash input
+	BalancedComponents bool
+
+	// Whether a short tag derived from the fingerprint is embedded at the end
+	// of each Cuid
+	FingerprintMarker bool
+
+	// Whether the session count is appended to each Cuid in a recoverable form
+	RecoverableSequence bool
+
+	// Whether each Cuid is chained to the previously generated Cuid
+	ChainedGeneration bool
+
+	// The encoding of the characters of generated Cuids
+	Encoding Encoding
+
+	// A source of random bytes for the entropy pool, crypto/rand is used when
+	// nil
+	EntropyReader io.Reader
+
+	// Maximum number of fingerprint characters that enter the hash input, the
+	// full fingerprint is used when zero
+	FingerprintInfluence int
+
+	// A function invoked once during initialization with the resolved
+	// fingerprint
+	FingerprintLogger func(fingerprint string)
+
+	// A function invoked on each generation with the hash input, for debugging
+	HashInputObserver func(input string)
+
+	// A function that extracts a trace id from a context, used by
+	// GenerateFromContext
+	TraceIdExtractor func(ctx context.Context) (string, bool)
+
+	// Number of checksum characters appended to each Cuid, disabled when zero
+	ChecksumLength int
+
+	// Number of shards the leading letter of each Cuid is spread across,
+	// disabled when zero
+	ShardHint int
+
+	// Order in which the components of the hash input are concatenated, the
+	// default order is used when nil
+	HashInputOrder []Component
+
+	// Whether the fingerprint is lowercased and trimmed of surrounding
+	// whitespace before use
+	NormalizeFingerprint bool
+
+	// Identifier of the shard or region folded into the fingerprint, used only
+	// when HasShardId is set
+	ShardId    uint16
+	HasShardId bool
+
+	// Interval at which a seedable random source is reseeded from
+	// crypto/rand, disabled when zero
+	ReseedInterval time.Duration
+
+	// The random source provided by WithRandomSource, if any
+	seedableSource *lockedSource
+
+	// Identifier of the tenant folded into the fingerprint, set by
+	// TenantGenerator and used only when hasTenantID is set
+	tenantID    string
+	hasTenantID bool
+
+	// Whether the default fingerprint is derived from the random function
+	// alone, as by NewReproducible
+	randomFingerprint bool
+
+	// The already resolved fingerprint restored by WithConfigSnapshot, into
+	// which the tenant id, process entropy, build version and shard id are not
+	// folded again
+	restoredFingerprint string
+
+	// Whether the length of each Cuid is embedded in its second character
+	EmbeddedLength bool
+
+	// Whether a character describing the enabled features is appended to each
+	// Cuid
+	FormatHeader bool
+
+	// A predicate used by Generator.Validate, IsCuid is used when nil
+	ValidateFunc func(id string) bool
+
+	// Whether the first letter of each Cuid is derived from the hash rather
+	// than drawn at random
+	DeterministicFirstLetter bool
+
+	// Whether the first letter of each Cuid is fixed, for analysis only
+	FixedFirstLetter bool
+
+	// Build version of the application folded into the fingerprint, disabled
+	// when empty
+	BuildVersion string
+
+	// Whether a process-unique value is folded into the fingerprint
+	ProcessMemoryEntropy bool
+
+	// Whether Generate returns an empty string rather than panicking when
+	// generation fails
+	NoPanic bool
+
+	// Maximum duration of a single GenerateE call, disabled when zero
+	GenerationTimeout time.Duration
+
+	// Whether the random function was replaced, e.g. for determinism, in which
+	// case the entropy pool is bypassed
+	customRandomFunc bool
 }
 
 type Counter interface {
 	Increment() int64
 }
 
+// A source of the current time, in milliseconds since the Unix epoch
+type Clock interface {
+	NowMillis() int64
+}
+
+// A Clock that reads the system time
+type RealClock struct{}
+
+func (RealClock) NowMillis() int64 {
+	return time.Now().UnixMilli()
+}
+
 type SessionCounter struct {
 	value int64
 }
@@ -56,148 +279,1808 @@ func (sc *SessionCounter) Increment() int64 {
 	return atomic.AddInt64(&sc.value, 1)
 }
 
+// Assumed size of a CPU cache line in bytes
+const cacheLineSize = 64
+
+// A session counter padded to fill a whole cache line, so that counters
+// allocated contiguously, e.g. in a slice of sharded counters, do not suffer
+// from false sharing when incremented concurrently
+type PaddedSessionCounter struct {
+	value int64
+	_     [cacheLineSize - 8]byte
+}
+
+func NewPaddedSessionCounter(initialCount int64) *PaddedSessionCounter {
+	return &PaddedSessionCounter{value: initialCount}
+}
+
+func (psc *PaddedSessionCounter) Increment() int64 {
+	return atomic.AddInt64(&psc.value, 1)
+}
+
+// A counter whose values are derived from a high-resolution monotonic clock,
+// for better temporal dispersion than an incrementing integer.
+//
+// Each value is the number of nanoseconds since the Unix epoch, as measured by
+// the monotonic clock from the moment the counter was created, so values are
+// unaffected by wall clock adjustments. Values are strictly increasing within
+// a process: if two calls observe the same reading, the later call returns
+// the previous value plus one. Values overflow int64 in the year 2262, and
+// always exceed MaxSessionCount.
+type ClockCounter struct {
+	start time.Time
+	last  int64
+}
+
+func NewClockCounter() *ClockCounter {
+	return &ClockCounter{start: time.Now()}
+}
+
+func (cc *ClockCounter) Increment() int64 {
+	reading := cc.start.UnixNano() + int64(time.Since(cc.start))
+
+	for {
+		last := atomic.LoadInt64(&cc.last)
+		next := reading
+		if next <= last {
+			next = last + 1
+		}
+		if atomic.CompareAndSwapInt64(&cc.last, last, next) {
+			return next
+		}
+	}
+}
+
 type Option func(*Config) error
 
+// A Cuid generator holding a resolved config
+type Generator struct {
+	config      *Config
+	fingerprint atomic.Value
+	entropyPool *entropyPool
+	stop        chan struct{}
+	closeOnce   sync.Once
+
+	// The resolved fingerprint before any rotation, from which rotated
+	// fingerprints and fingerprint markers are derived
+	baseFingerprint string
+
+	counterOverflowed atomic.Bool
+
+	// Highest session count observed by checkCounterOverflow, lowered only
+	// when a wrapping counter wraps around
+	counterHighWater atomic.Int64
+
+	// Whether the session counter may wrap around, i.e. is not one of the
+	// monotonic counters of this package
+	counterWraps bool
+
+	// The previously generated Cuid, guarded by chainMutex, used only when
+	// ChainedGeneration is set
+	chainMutex sync.Mutex
+	previousID string
+
+	// Number of generated Cuids starting with each letter, used only when
+	// DistributionTracking is set
+	distribution [26]atomic.Int64
+}
+
 // Initializes the Cuid generator with default or user-defined config options
 //
 // Returns a function that can be called to generate Cuids using the initialized config
 func Init(options ...Option) (func() string, error) {
+	generator, err := NewGenerator(options...)
+	if err != nil {
+		return func() string { return "" }, err
+	}
+
+	return generator.Generate, nil
+}
+
+// Creates a new Cuid generator with default or user-defined config options
+func NewGenerator(options ...Option) (*Generator, error) {
+	return newGenerator(newDefaultConfig(), options...)
+}
+
+// Creates the config that options are applied to by NewGenerator
+func newDefaultConfig() *Config {
+	return &Config{
+		RandomFunc:     rand.Float64,
+		SessionCounter: NewSessionCounter(createInitialSessionCount()),
+		Length:         DefaultIdLength,
+		TimeFunc:       time.Now,
+		TimeResolution: time.Millisecond,
+	}
+}
+
+// Creates a new Cuid generator whose output is fully determined by the given
+// seed, so the Nth call to Generate always yields the same Cuid.
+//
+// The random source, fingerprint and initial session count are all derived
+// from the seed. Time is fixed at the Unix epoch unless overridden with
+// WithTimeFunc. Options that replace the random source or counter will break
+// reproducibility.
+func NewReproducible(seed int64, options ...Option) (*Generator, error) {
+	source := rand.New(rand.NewSource(seed))
+	mutex := &sync.Mutex{}
+	randomFunc := func() float64 {
+		mutex.Lock()
+		defer mutex.Unlock()
+		return source.Float64()
+	}
+
 	initialSessionCount := int64(
-		math.Floor(rand.Float64() * float64(MaxSessionCount)),
+		math.Floor(randomFunc() * float64(MaxSessionCount)),
 	)
 
 	config := &Config{
-		RandomFunc:     rand.Float64,
-		SessionCounter: NewSessionCounter(initialSessionCount),
-		Length:         DefaultIdLength,
-		Fingerprint:    createFingerprint(rand.Float64, getEnvironmentKeyString()),
+		RandomFunc:        randomFunc,
+		customRandomFunc:  true,
+		SessionCounter:    NewSessionCounter(initialSessionCount),
+		Length:            DefaultIdLength,
+		randomFingerprint: true,
+		TimeFunc:          func() time.Time { return time.UnixMilli(0) },
+		TimeResolution:    time.Millisecond,
+	}
+
+	return newGenerator(config, options...)
+}
+
+func newGenerator(config *Config, options ...Option) (*Generator, error) {
+	if configErr := configure(config, options...); configErr != nil {
+		return nil, configErr
+	}
+
+	// Derived only when no option provided a fingerprint, since it is the most
+	// expensive part of creating a generator
+	if len(config.FingerprintEnvironmentKeys) > 0 {
+		config.Fingerprint = createFingerprint(
+			config.RandomFunc,
+			getEnvironmentKeyString(config.FingerprintEnvironmentKeys...),
+		)
+	} else if config.Fingerprint == "" {
+		config.Fingerprint = createConfigFingerprint(config)
 	}
 
+	generator := &Generator{config: config, stop: make(chan struct{})}
+	generator.counterWraps = isWrappingCounter(config.SessionCounter)
+	generator.baseFingerprint = config.Fingerprint
+	if config.Fingerprint != config.restoredFingerprint {
+		generator.baseFingerprint = resolveFingerprint(config, config.Fingerprint)
+	}
+	generator.fingerprint.Store(generator.baseFingerprint)
+
+	if config.FingerprintLogger != nil {
+		config.FingerprintLogger(generator.fingerprint.Load().(string))
+	}
+
+	if (config.EntropyPoolSize > 0 || config.EntropyReader != nil) && !config.customRandomFunc {
+		poolSize := config.EntropyPoolSize
+		if poolSize == 0 {
+			poolSize = DefaultEntropyPoolSize
+		}
+
+		entropyReader := config.EntropyReader
+		if entropyReader == nil {
+			entropyReader = cryptorand.Reader
+		}
+
+		generator.entropyPool = newEntropyPool(poolSize, entropyReader)
+	}
+
+	if config.FingerprintRotationInterval > 0 {
+		go generator.rotateFingerprint(config.FingerprintRotationInterval)
+	}
+
+	// Reseeding is a no-op unless a seedable random source is used
+	if config.ReseedInterval > 0 && config.seedableSource != nil {
+		go generator.reseed(config.ReseedInterval)
+	}
+
+	return generator, nil
+}
+
+// Applies the options to the config and validates the result, without
+// deriving the fingerprint or invoking any callbacks of the resolved config
+func configure(config *Config, options ...Option) error {
 	for _, option := range options {
 		if option != nil {
 			if applyErr := option(config); applyErr != nil {
-				return func() string { return "" }, applyErr
+				return applyErr
 			}
 		}
 	}
 
-	return func() string {
-		firstLetter := getRandomAlphabet(config.RandomFunc)
-		time := strconv.FormatInt(time.Now().UnixMilli(), 36)
-		count := strconv.FormatInt(config.SessionCounter.Increment(), 36)
-		salt := createEntropy(config.Length, config.RandomFunc)
-		hashInput := time + salt + count + config.Fingerprint
-		hashDigest := firstLetter + hash(hashInput)[1:config.Length]
+	if len(config.FingerprintEnvironmentKeys) > 0 && config.Fingerprint != "" {
+		return fmt.Errorf("Error: stable fingerprint keys cannot be combined with an explicitly provided fingerprint")
+	}
 
-		return hashDigest
-	}, nil
+	return validateConfig(config)
 }
 
-// Generates Cuids using default config options
-var Generate, _ = Init()
+// Normalizes the fingerprint and folds any tenant id, process entropy, build
+// version and shard id into it, so that they survive fingerprint rotation and are
+// independent of the order of options
+func resolveFingerprint(config *Config, fingerprint string) string {
+	if config.NormalizeFingerprint {
+		fingerprint = strings.ToLower(strings.TrimSpace(fingerprint))
+	}
 
-// Checks whether a given Cuid has a valid form and length
-func IsCuid(cuid string) bool {
-	length := len(cuid)
-	hasValidForm, _ := regexp.MatchString("^[a-z][0-9a-z]+$", cuid)
+	if config.hasTenantID {
+		fingerprint = Hash(fingerprint + config.tenantID)[1:]
+	}
 
-	if hasValidForm && length >= MinIdLength && length <= MaxIdLength {
-		return true
+	if config.ProcessMemoryEntropy {
+		fingerprint = Hash(fingerprint + "process" + getProcessEntropy())[1:]
 	}
 
-	return false
-}
+	if config.BuildVersion != "" {
+		fingerprint = Hash(fingerprint + "version" + config.BuildVersion)[1:]
+	}
 
-// A custom function that will generate a random floating-point value between 0 and 1
-func WithRandomFunc(randomFunc func() float64) Option {
-	return func(config *Config) error {
-		randomness := randomFunc()
-		if randomness < 0 || randomness > 1 {
-			return fmt.Errorf("Error: the provided random function does not generate a value between 0 and 1")
-		}
-		config.RandomFunc = randomFunc
-		return nil
+	if !config.HasShardId {
+		return fingerprint
 	}
+
+	return Hash(fingerprint + "shard" + strconv.FormatUint(uint64(config.ShardId), 36))[1:]
 }
 
-// A custom counter that will be used to affect the entropy of successive id
-// generation calls
-func WithSessionCounter(sessionCounter Counter) Option {
-	return func(config *Config) error {
-		config.SessionCounter = sessionCounter
-		return nil
+// Caps how many characters of the fingerprint enter the hash input, the full
+// fingerprint is used when the limit is zero
+func limitFingerprint(fingerprint string, limit int) string {
+	if limit <= 0 || len(fingerprint) <= limit {
+		return fingerprint
 	}
+
+	return fingerprint[:limit]
 }
 
-// Configures the length of the generated Cuid
-//
-// Min Length = 2, Max Length = 32
-func WithLength(length int) Option {
-	return func(config *Config) error {
-		if length < MinIdLength || length > MaxIdLength {
-			return fmt.Errorf("Error: Can only generate Cuid's with a length between %v and %v", MinIdLength, MaxIdLength)
+// Checks that the resolved config does not combine incompatible options
+func validateConfig(config *Config) error {
+	if config.FingerprintMarker && config.RecoverableSequence {
+		return fmt.Errorf("Error: the fingerprint marker and recoverable sequence options cannot be combined")
+	}
+
+	if config.Encoding != Base36 &&
+		(config.SortableDescending || config.DateBucketUnit > 0 || config.FingerprintMarker ||
+			config.RecoverableSequence || config.ChecksumLength > 0) {
+		return fmt.Errorf("Error: non-base36 encodings cannot be combined with sortable, date bucket, fingerprint marker, recoverable sequence or checksum options")
+	}
+
+	if config.SortableDescending && config.DateBucketUnit > 0 {
+		return fmt.Errorf("Error: the sortable and date bucket prefix options cannot be combined")
+	}
+
+	if config.DistributionTracking && (config.SortableDescending || config.DateBucketUnit > 0 || config.ShardHint > 0) {
+		return fmt.Errorf("Error: distribution tracking cannot be combined with sortable, date bucket or shard hint options")
+	}
+
+	if config.DateBucketUnit > 0 {
+		if err := validateDateBucket(config); err != nil {
+			return err
 		}
-		config.Length = length
-		return nil
 	}
+
+	if config.EmbeddedLength &&
+		(config.Encoding != Base36 || config.SortableDescending || config.DateBucketUnit > 0) {
+		return fmt.Errorf("Error: the embedded length option cannot be combined with non-base36 encodings, sortable or date bucket options")
+	}
+
+	if config.EmbeddedLength && config.Length > MaxIdLength {
+		return fmt.Errorf("Error: the embedded length option requires a length of at most %v", MaxIdLength)
+	}
+
+	if config.FormatHeader && config.Encoding != Base36 {
+		return fmt.Errorf("Error: the format header option cannot be combined with non-base36 encodings")
+	}
+
+	if config.FixedFirstLetter && config.DeterministicFirstLetter {
+		return fmt.Errorf("Error: the fixed and deterministic first letter options cannot be combined")
+	}
+
+	if config.DeterministicFirstLetter && config.Encoding != Base36 {
+		return fmt.Errorf("Error: the deterministic first letter option cannot be combined with non-base36 encodings")
+	}
+
+	if config.ShardHint > 0 && (config.Encoding != Base36 || config.SortableDescending || config.DateBucketUnit > 0) {
+		return fmt.Errorf("Error: the shard hint option cannot be combined with non-base36 encodings, sortable or date bucket options")
+	}
+
+	if config.EnvironmentFingerprintDisallowed && usesEnvironmentFingerprint(config) {
+		return fmt.Errorf("Error: stable fingerprint keys and shared fingerprints cannot be combined with a policy that disallows environment fingerprinting")
+	}
+
+	if config.ChainedGeneration && config.GenerationTimeout > 0 {
+		return fmt.Errorf("Error: chained generation cannot be combined with a generation timeout")
+	}
+
+	if config.ChainedGeneration &&
+		(config.Encoding != Base36 || config.FingerprintMarker || config.RecoverableSequence ||
+			config.ChecksumLength > 0 || config.FormatHeader) {
+		return fmt.Errorf("Error: chained generation cannot be combined with non-base36 encodings, fingerprint marker, recoverable sequence, checksum or format header options")
+	}
+
+	if config.ChecksumLength > 0 && (config.FingerprintMarker || config.RecoverableSequence) {
+		return fmt.Errorf("Error: checksums cannot be combined with fingerprint marker or recoverable sequence options")
+	}
+
+	if config.EntropyLength > 0 && config.EntropyLength < config.MinEntropyLength {
+		return fmt.Errorf("Error: the entropy length (%v) is below the minimum entropy length (%v)", config.EntropyLength, config.MinEntropyLength)
+	}
+
+	if minLength := getMinLength(config); config.Length < minLength {
+		return fmt.Errorf("Error: Can only generate Cuid's with a length of at least %v for the configured options", minLength)
+	}
+
+	return nil
 }
 
-// A unique string that will be used by the id generator to help prevent
-// collisions when generating Cuids in a distributed system.
-func WithFingerprint(fingerprint string) Option {
-	return func(config *Config) error {
-		config.Fingerprint = fingerprint
-		return nil
+// Returns the minimum length of a Cuid that can be generated with the given
+// config, accounting for options that reserve characters
+func getMinLength(config *Config) int {
+	minLength := MinIdLength
+
+	if config.SortableDescending {
+		minLength = MinSortableIdLength
+	}
+
+	if config.DateBucketUnit > 0 {
+		minLength = MinDateBucketIdLength
+	}
+
+	if config.FingerprintMarker {
+		minLength += fingerprintMarkerLength
+	}
+
+	if config.RecoverableSequence {
+		minLength += sequenceWidth
+	}
+
+	minLength += config.ChecksumLength
+
+	if config.ChainedGeneration {
+		minLength += chainLinkLength
+	}
+
+	if config.FormatHeader {
+		minLength += formatHeaderLength
+	}
+
+	if config.EmbeddedLength {
+		minLength++
 	}
+
+	return minLength
 }
 
-func createFingerprint(randomFunc func() float64, envKeyString string) string {
-	sourceString := createEntropy(MaxIdLength, randomFunc)
+// Returns the number of random characters in the salt, which is the entropy
+// length if configured or the length of the Cuid otherwise, raised to the
+// minimum entropy length
+func getSaltLength(config *Config) int {
+	saltLength := config.Length
+	if config.EntropyLength > 0 {
+		saltLength = config.EntropyLength
+	}
 
-	if len(envKeyString) > 0 {
-		sourceString += envKeyString
+	if saltLength < config.MinEntropyLength {
+		saltLength = config.MinEntropyLength
 	}
 
-	sourceStringHash := hash(sourceString)
+	return saltLength
+}
+
+func (g *Generator) createSalt() (string, error) {
+	saltLength := getSaltLength(g.config)
 
-	return sourceStringHash[1:]
+	if g.entropyPool != nil {
+		return g.entropyPool.createEntropy(saltLength)
+	}
+
+	return createEntropy(saltLength, g.config.RandomFunc), nil
 }
 
-func createEntropy(length int, randomFunc func() float64) string {
-	entropy := ""
+func (g *Generator) isForbidden(cuid string) bool {
+	_, forbidden := g.config.ForbiddenIDs[cuid]
+	return forbidden
+}
 
-	for len(entropy) < length {
-		randomness := int64(math.Floor(randomFunc() * 36))
-		entropy += strconv.FormatInt(randomness, 36)
+func (g *Generator) containsRejectedWord(cuid string) bool {
+	for _, word := range g.config.RejectedWords {
+		if strings.Contains(cuid, word) {
+			return true
+		}
 	}
 
-	return entropy
+	return false
 }
 
-func getEnvironmentKeyString() string {
-	env := os.Environ()
+// Minimum drop below the highest observed session count that is treated as a
+// wrap around rather than a count observed out of order by concurrent callers
+const counterWrapDistance = MaxSessionCount / 2
 
-	keys := []string{}
+// Invokes the counter overflow handler, if any, exactly once each time the
+// session counter crosses MaxSessionCount.
+//
+// Concurrent callers may observe counts out of order, so counts below the
+// highest observed count are ignored, unless a custom counter drops by at
+// least counterWrapDistance, which is treated as a wrap around and allows the
+// handler to fire again. The counters of this package never wrap around.
+func (g *Generator) checkCounterOverflow(sessionCount int64) {
+	if g.config.CounterOverflowHandler == nil {
+		return
+	}
 
-	// Discard values of environment variables
-	for _, variable := range env {
-		key := variable[:strings.IndexByte(variable, '=')]
-		keys = append(keys, key)
+	for {
+		highWater := g.counterHighWater.Load()
+
+		if sessionCount > highWater {
+			if !g.counterHighWater.CompareAndSwap(highWater, sessionCount) {
+				continue
+			}
+
+			if sessionCount > MaxSessionCount && g.counterOverflowed.CompareAndSwap(false, true) {
+				g.config.CounterOverflowHandler()
+			}
+			return
+		}
+
+		if !g.counterWraps || highWater-sessionCount < counterWrapDistance {
+			return
+		}
+
+		if g.counterHighWater.CompareAndSwap(highWater, sessionCount) {
+			g.counterOverflowed.Store(false)
+			return
+		}
 	}
+}
 
-	return strings.Join(keys, "")
+// Checks whether a counter may wrap around, which only custom counters can
+func isWrappingCounter(counter Counter) bool {
+	switch counter.(type) {
+	case *SessionCounter, *PaddedSessionCounter, *ClockCounter:
+		return false
+	default:
+		return true
+	}
 }
 
-func hash(input string) string {
-	hash := sha3.New512()
-	hash.Write([]byte(input))
-	hashDigest := hash.Sum(nil)
-	return new(big.Int).SetBytes(hashDigest).Text(36)[1:]
+// Stops any background work started by the generator, such as fingerprint
+// rotation. It is safe to call Close multiple times.
+func (g *Generator) Close() {
+	g.closeOnce.Do(func() {
+		close(g.stop)
+	})
+}
+
+func (g *Generator) rotateFingerprint(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-g.stop:
+			return
+		case <-ticker.C:
+			g.fingerprint.Store(createRotatedFingerprint(g.baseFingerprint, g.config.RandomFunc))
+		}
+	}
+}
+
+// Generates a new Cuid using the generator's config
+//
+// Panics if the entropy source fails, unless WithNoPanic is set, in which case
+// an empty string is returned. Use GenerateE to handle such failures.
+func (g *Generator) Generate() string {
+	cuid, err := g.generateFiltered(g.config.TimeFunc(), "")
+	if err != nil {
+		return g.handleGenerateError(err)
+	}
+
+	return cuid
+}
+
+// Panics with the given generation error, or returns an empty Cuid when
+// WithNoPanic is set
+func (g *Generator) handleGenerateError(err error) string {
+	if g.config.NoPanic {
+		return ""
+	}
+
+	panic(err)
+}
+
+// Generates a new Cuid using the generator's config, returning an error if
+// generation fails, e.g. due to a failing entropy source or by exceeding the
+// configured generation timeout
+func (g *Generator) GenerateE() (string, error) {
+	if g.config.GenerationTimeout <= 0 {
+		return g.generateFiltered(g.config.TimeFunc(), "")
+	}
+
+	type generationResult struct {
+		cuid string
+		err  error
+	}
+
+	// Buffered, so that a generation that completes after the timeout does
+	// not block forever
+	result := make(chan generationResult, 1)
+	go func() {
+		cuid, err := g.generateFiltered(g.config.TimeFunc(), "")
+		result <- generationResult{cuid: cuid, err: err}
+	}()
+
+	timer := time.NewTimer(g.config.GenerationTimeout)
+	defer timer.Stop()
+
+	select {
+	case generated := <-result:
+		return generated.cuid, generated.err
+	case <-timer.C:
+		return "", ErrGenerationTimeout
+	}
+}
+
+// Generates a batch of n Cuids that are guaranteed to be distinct from one
+// another, regenerating any Cuid that duplicates an earlier one.
+//
+// Duplicates are practically impossible, but if more than
+// MaxUniqueBatchRetries are encountered, an error is returned along with the
+// distinct Cuids generated so far.
+func (g *Generator) GenerateNUnique(n int) ([]string, error) {
+	if n < 0 {
+		return nil, fmt.Errorf("Error: cannot generate a negative number of Cuids")
+	}
+
+	cuids := make([]string, 0, n)
+	set := make(map[string]struct{}, n)
+
+	for retries := 0; len(cuids) < n; {
+		cuid, err := g.GenerateE()
+		if err != nil {
+			return cuids, err
+		}
+
+		if _, exists := set[cuid]; exists {
+			retries++
+			if retries > MaxUniqueBatchRetries {
+				return cuids, fmt.Errorf("Error: could not generate %v distinct Cuids after %v retries", n, MaxUniqueBatchRetries)
+			}
+			continue
+		}
+
+		set[cuid] = struct{}{}
+		cuids = append(cuids, cuid)
+	}
+
+	return cuids, nil
+}
+
+// Generates Cuids until one satisfies the given predicate, e.g. one that is
+// not already present in an external store, returning an error if none does
+// within maxAttempts attempts or if generation fails.
+//
+// The predicate is called once per attempt, so it is the caller's
+// responsibility to keep it fast.
+func (g *Generator) GenerateUntil(predicate func(cuid string) bool, maxAttempts int) (string, error) {
+	if predicate == nil {
+		return "", fmt.Errorf("Error: the provided predicate must not be nil")
+	}
+
+	for attempt := 0; attempt < maxAttempts; attempt++ {
+		cuid, err := g.GenerateE()
+		if err != nil {
+			return "", err
+		}
+
+		if predicate(cuid) {
+			return cuid, nil
+		}
+	}
+
+	return "", fmt.Errorf("Error: could not generate a Cuid satisfying the predicate after %v attempts", maxAttempts)
+}
+
+// Generates n Cuids that share a single timestamp, e.g. for related records
+// created by one logical operation, while remaining unique through the session
+// counter and salt.
+//
+// Returns an empty slice when n is not positive. Like Generate, it panics if
+// generation fails, unless WithNoPanic is set, in which case the Cuids
+// generated so far are returned.
+func (g *Generator) GenerateGroup(n int) []string {
+	cuids := []string{}
+	now := g.config.TimeFunc()
+
+	for len(cuids) < n {
+		cuid, err := g.generateFiltered(now, "")
+		if err != nil {
+			g.handleGenerateError(err)
+			return cuids
+		}
+		cuids = append(cuids, cuid)
+	}
+
+	return cuids
+}
+
+// Generates a new Cuid for a child of the given parent id, e.g. in tree
+// structured data, folding the parent id into its entropy. Uniqueness is still
+// provided by the timestamp, session counter and salt, so children of the same
+// parent are distinct.
+//
+// The parent id is hashed along with the rest of the entropy, so the result is
+// a valid Cuid, but the relationship cannot be derived from the Cuids alone
+// and must be recorded separately if it needs to be audited.
+func (g *Generator) GenerateChild(parentID string) string {
+	cuid, err := g.generateFiltered(g.config.TimeFunc(), "parent"+parentID)
+	if err != nil {
+		return g.handleGenerateError(err)
+	}
+
+	return cuid
+}
+
+// Generates a single Cuid of the given length, e.g. for a one-off short id,
+// leaving the generator's configured length untouched. Returns an error if the
+// length is out of bounds for the configured options or if generation fails.
+func (g *Generator) GenerateWithLength(length int) (string, error) {
+	if length < MinIdLength || length > MaxIdLength {
+		return "", fmt.Errorf("Error: Can only generate Cuid's with a length between %v and %v", MinIdLength, MaxIdLength)
+	}
+
+	if minLength := getMinLength(g.config); length < minLength {
+		return "", fmt.Errorf("Error: Can only generate Cuid's with a length of at least %v for the configured options", minLength)
+	}
+
+	return g.generateFilteredWithLength(g.config.TimeFunc(), "", length)
+}
+
+// Generates a new Cuid at the given time, regenerating it if it contains a
+// rejected word
+func (g *Generator) generateFiltered(now time.Time, extraEntropy string) (string, error) {
+	return g.generateFilteredWithLength(now, extraEntropy, g.config.Length)
+}
+
+// Generates a new Cuid of the given length at the given time, regenerating it
+// if it contains a rejected word
+func (g *Generator) generateFilteredWithLength(now time.Time, extraEntropy string, length int) (string, error) {
+	if g.config.ChainedGeneration {
+		g.chainMutex.Lock()
+		defer g.chainMutex.Unlock()
+	}
+
+	cuid, err := g.generate(now, extraEntropy, length)
+	if err != nil {
+		return "", err
+	}
+
+	for retries := 0; g.containsRejectedWord(cuid); retries++ {
+		if retries == MaxRejectedWordRetries {
+			log.Printf("Warning: could not generate a Cuid without rejected words after %v retries", retries)
+			break
+		}
+		if cuid, err = g.generate(now, extraEntropy, length); err != nil {
+			return "", err
+		}
+	}
+
+	for retries := 0; g.isForbidden(cuid); retries++ {
+		if retries == MaxForbiddenIDRetries {
+			return "", fmt.Errorf("Error: could not generate a Cuid that is not a forbidden id after %v retries", retries)
+		}
+		if cuid, err = g.generate(now, extraEntropy, length); err != nil {
+			return "", err
+		}
+	}
+
+	if g.config.ChainedGeneration {
+		g.previousID = cuid
+	}
+
+	if g.config.DistributionTracking {
+		g.trackDistribution(cuid)
+	}
+
+	return cuid, nil
+}
+
+// Generates a new Cuid of the given length at the given time, folding any
+// extra entropy into the hash input
+func (g *Generator) generate(now time.Time, extraEntropy string, fullLength int) (string, error) {
+	config := g.config
+
+	now = now.Add(config.TimeOffset)
+
+	// Length of the Cuid excluding any format header
+	length := fullLength
+	if config.FormatHeader {
+		length -= formatHeaderLength
+	}
+
+	var firstLetter string
+	if config.FixedFirstLetter {
+		firstLetter = fixedFirstLetter
+	} else if config.Encoding == CrockfordBase32 {
+		firstLetter = getRandomCharacter(crockfordBase32Letters, config.RandomFunc)
+	} else if !config.DeterministicFirstLetter {
+		firstLetter = getRandomAlphabet(config.RandomFunc)
+	}
+	time := strconv.FormatInt(getTimestamp(now, config.TimeResolution), 36)
+	sessionCount := config.SessionCounter.Increment()
+	g.checkCounterOverflow(sessionCount)
+	count := formatCount(sessionCount, config.CounterWidth)
+	if config.BalancedComponents {
+		time = padComponent(time, balancedTimeWidth)
+		if config.CounterWidth <= 0 {
+			count = padComponent(count, balancedCounterWidth)
+		}
+	}
+	salt, err := g.createSalt()
+	if err != nil {
+		return "", err
+	}
+	fingerprint := g.fingerprint.Load().(string)
+	hashFingerprint := limitFingerprint(fingerprint, config.FingerprintInfluence)
+	hashInput := time + salt + count + hashFingerprint + extraEntropy
+	if config.HashInputOrder != nil {
+		hashInput = createHashInput(config.HashInputOrder, time, salt, count, hashFingerprint) + extraEntropy
+	}
+	if config.ChainedGeneration {
+		hashInput += "previous" + g.previousID
+	}
+	if config.HashInputObserver != nil {
+		config.HashInputObserver(hashInput)
+	}
+
+	var hashDigest string
+	switch {
+	case config.SortableDescending:
+		prefix, err := createDescendingTimePrefix(now)
+		if err != nil {
+			return "", err
+		}
+		hashDigest = prefix + Hash(hashInput)[1:length-len(prefix)+1]
+	case config.DateBucketUnit > 0:
+		prefix, err := createDateBucketPrefix(now, config.DateBucketUnit)
+		if err != nil {
+			return "", err
+		}
+		hashDigest = prefix + Hash(hashInput)[1:length-len(prefix)+1]
+	case config.Encoding == CrockfordBase32:
+		hashDigest = firstLetter + hashCrockfordBase32(hashInput)[1:length]
+	default:
+		digest := Hash(hashInput)
+		if config.DeterministicFirstLetter {
+			firstLetter = createDeterministicFirstLetter(digest)
+		}
+		hashDigest = firstLetter + digest[1:length]
+	}
+
+	if config.ShardHint > 0 {
+		hashDigest = createShardHintLetter(hashDigest[1:], firstLetter, config.ShardHint) + hashDigest[1:]
+	}
+
+	if config.EmbeddedLength {
+		hashDigest = embedLength(hashDigest, fullLength)
+	}
+
+	if config.FingerprintMarker {
+		hashDigest = hashDigest[:length-fingerprintMarkerLength] + createFingerprintMarker(g.baseFingerprint)
+	}
+
+	if config.RecoverableSequence {
+		hashDigest = hashDigest[:length-sequenceWidth] + encodeSequence(sessionCount)
+	}
+
+	if config.ChainedGeneration {
+		hashDigest = hashDigest[:length-chainLinkLength] + createChainLink(g.previousID)
+	}
+
+	if config.ChecksumLength > 0 {
+		body := hashDigest[:length-config.ChecksumLength]
+		hashDigest = body + createChecksum(body, config.ChecksumLength)
+	}
+
+	if config.FormatHeader {
+		hashDigest += createFormatHeader(config)
+	}
+
+	return hashDigest, nil
+}
+
+// A fixed-size, value-type representation of a Cuid, suitable for use as a
+// map key without a heap-allocated string.
+//
+// Cuids shorter than MaxIdLength are right-padded with zero bytes. Since a
+// Cuid never contains a zero byte, the used length is recovered from the
+// position of the first padding byte.
+type FixedCuid [MaxIdLength]byte
+
+// Returns the number of bytes used by the Cuid, excluding padding
+func (f FixedCuid) Len() int {
+	for index, char := range f {
+		if char == 0 {
+			return index
+		}
+	}
+
+	return len(f)
+}
+
+// Converts the fixed-size Cuid back to a string, excluding padding
+func (f FixedCuid) String() string {
+	return string(f[:f.Len()])
+}
+
+// Generates a new Cuid as a fixed-size, zero-padded byte array.
+//
+// Like Generate, it panics if generation fails, or if the configured length
+// exceeds MaxIdLength, e.g. one set by WithLengthUnsafe, unless WithNoPanic is
+// set, in which case a zero-valued array is returned.
+func (g *Generator) GenerateArray() FixedCuid {
+	var fixedCuid FixedCuid
+
+	if g.config.Length > MaxIdLength {
+		g.handleGenerateError(fmt.Errorf("Error: the length (%v) exceeds the capacity of a FixedCuid (%v)", g.config.Length, MaxIdLength))
+		return fixedCuid
+	}
+
+	copy(fixedCuid[:], g.Generate())
+	return fixedCuid
+}
+
+var (
+	defaultGenerateOnce sync.Once
+	defaultGenerate     func() string
+)
+
+// Generates Cuids using default config options.
+//
+// The default generator is initialized on first use, see Warmup.
+var Generate = func() string {
+	return getDefaultGenerate()()
+}
+
+// Initializes the default generator used by Generate ahead of time, so that
+// the first call to Generate does not pay the cost of deriving the
+// fingerprint, e.g. when called during application startup.
+//
+// Safe to call any number of times, before or after the first Generate.
+func Warmup() {
+	getDefaultGenerate()
+}
+
+func getDefaultGenerate() func() string {
+	defaultGenerateOnce.Do(func() {
+		defaultGenerate, _ = Init()
+	})
+
+	return defaultGenerate
+}
+
+var cuidRegex = regexp.MustCompile("^[a-z][0-9a-z]*$")
+
+// Returns a function that checks whether a given Cuid has a valid form and a
+// length between the provided min and max bounds (inclusive), for repeated
+// validation against the same bounds, e.g. in hot loops
+func NewCuidChecker(min, max int) func(cuid string) bool {
+	return func(cuid string) bool {
+		length := len(cuid)
+		return length >= min && length <= max && cuidRegex.MatchString(cuid)
+	}
+}
+
+// Checks whether a given Cuid has a valid form and length
+func IsCuid(cuid string) bool {
+	return IsCuidWithBounds(cuid, MinIdLength, MaxIdLength)
+}
+
+// Checks whether a given Cuid has a valid form and a length between the
+// provided min and max bounds (inclusive)
+func IsCuidWithBounds(cuid string, min, max int) bool {
+	length := len(cuid)
+	hasValidForm := cuidRegex.MatchString(cuid)
+
+	if hasValidForm && length >= min && length <= max {
+		return true
+	}
+
+	return false
+}
+
+// Maximum length of a DNS label, as defined by RFC 1035
+const maxDNSLabelLength = 63
+
+var dnsLabelRegex = regexp.MustCompile("^[a-z]([0-9a-z-]*[0-9a-z])?$")
+
+// Checks whether an id, e.g. a Cuid with a prefix, can be used as a DNS label
+// such as a subdomain: at most 63 characters, starting with a lowercase letter,
+// ending with a lowercase letter or digit, and otherwise containing only
+// lowercase letters, digits and hyphens.
+//
+// Every Cuid generated with the default alphabet satisfies this, since Cuids
+// are at most 32 characters long. Ids with prefixes or separators other than
+// hyphens, such as underscores, do not.
+func IsDNSLabelCuid(id string) bool {
+	return len(id) <= maxDNSLabelLength && dnsLabelRegex.MatchString(id)
+}
+
+// Maximum length of a file name on common filesystems
+const maxFileNameLength = 255
+
+var fileNameRegex = regexp.MustCompile("^[0-9a-z_]([0-9a-z_.-]*[0-9a-z_])?$")
+
+// Names reserved by Windows regardless of case, which a short Cuid could match
+var reservedFileNames = map[string]struct{}{
+	"con": {}, "prn": {}, "aux": {}, "nul": {},
+	"com1": {}, "com2": {}, "com3": {}, "com4": {}, "com5": {}, "com6": {}, "com7": {}, "com8": {}, "com9": {},
+	"lpt1": {}, "lpt2": {}, "lpt3": {}, "lpt4": {}, "lpt5": {}, "lpt6": {}, "lpt7": {}, "lpt8": {}, "lpt9": {},
+}
+
+// Checks whether an id, e.g. a Cuid with a prefix, can be used as a file name
+// on common filesystems, including case-insensitive ones: at most 255
+// characters of lowercase letters, digits, underscores, hyphens and dots, not
+// starting with a hyphen or dot, not ending with a hyphen or dot, and not a
+// name reserved by Windows such as "con".
+//
+// Cuids generated with the default alphabet only contain lowercase letters and
+// digits, so they satisfy this unless they are one of the reserved names,
+// which only Cuids of 3 or 4 characters can be. Prefixes or separators with
+// characters such as slashes, colons or capital letters do not.
+func IsFilesystemSafe(id string) bool {
+	if len(id) > maxFileNameLength || !fileNameRegex.MatchString(id) {
+		return false
+	}
+
+	_, reserved := reservedFileNames[id]
+
+	return !reserved
+}
+
+// Checks whether two ids of equal length differ in at most maxDistance
+// character positions, i.e. their Hamming distance, e.g. to flag likely
+// transcription errors in user-entered data. Ids of unequal length are never
+// near duplicates.
+func AreNearDuplicates(a, b string, maxDistance int) bool {
+	if len(a) != len(b) || maxDistance < 0 {
+		return false
+	}
+
+	distance := 0
+	for index := 0; index < len(a); index++ {
+		if a[index] != b[index] {
+			distance++
+			if distance > maxDistance {
+				return false
+			}
+		}
+	}
+
+	return true
+}
+
+// Returns the ids that are not valid Cuids according to IsCuid, preserving
+// their order, e.g. to report malformed ids in an ingested batch
+func FilterInvalid(ids []string) []string {
+	invalid := []string{}
+	for _, id := range ids {
+		if !IsCuid(id) {
+			invalid = append(invalid, id)
+		}
+	}
+
+	return invalid
+}
+
+// A Cuid that has been validated, e.g. by Parse
+type CUID string
+
+// Parses and validates a string as a Cuid, returning an error that describes
+// why the string is not a valid Cuid
+func Parse(s string) (CUID, error) {
+	switch ClassifyInvalid(s) {
+	case TooShort:
+		return "", fmt.Errorf("Error: Cuid (%v) is too short, min length = %v", s, MinIdLength)
+	case TooLong:
+		return "", fmt.Errorf("Error: Cuid (%v) is too long, max length = %v", s, MaxIdLength)
+	case BadFirstChar:
+		return "", fmt.Errorf("Error: Cuid (%v) must start with a lowercase letter", s)
+	case BadBodyChar:
+		index := findInvalidBodyChar(s)
+		return "", fmt.Errorf("Error: Cuid (%v) contains an invalid character %q at position %v", s, s[index], index)
+	}
+
+	return CUID(s), nil
+}
+
+// Parses a string as a Cuid, panicking if it is not a valid Cuid.
+//
+// Intended for test fixtures and other known-valid values
+func MustParse(s string) CUID {
+	cuid, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+
+	return cuid
+}
+
+// The reason a string is not a valid Cuid, as reported by ClassifyInvalid
+type InvalidReason int
+
+const (
+	// The string is a valid Cuid
+	Valid InvalidReason = iota
+
+	// The string is shorter than MinIdLength, e.g. because it was truncated
+	TooShort
+
+	// The string is longer than MaxIdLength, e.g. because several Cuids were
+	// concatenated
+	TooLong
+
+	// The string does not start with a lowercase letter
+	BadFirstChar
+
+	// The string contains a character other than a lowercase letter or digit
+	// after the first character
+	BadBodyChar
+)
+
+// Classifies why a string is not a valid Cuid, or returns Valid if it is.
+//
+// Checks are applied in the order of the InvalidReason constants, so a string
+// that is both too long and contains invalid characters is reported as TooLong.
+func ClassifyInvalid(cuid string) InvalidReason {
+	if len(cuid) < MinIdLength {
+		return TooShort
+	}
+
+	if len(cuid) > MaxIdLength {
+		return TooLong
+	}
+
+	if cuid[0] < 'a' || cuid[0] > 'z' {
+		return BadFirstChar
+	}
+
+	if findInvalidBodyChar(cuid) >= 0 {
+		return BadBodyChar
+	}
+
+	return Valid
+}
+
+// Returns the position of the first character after the first that is not a
+// lowercase letter or digit, or -1 if there is none
+func findInvalidBodyChar(cuid string) int {
+	for index := 1; index < len(cuid); index++ {
+		if !strings.ContainsRune(Base36Digits, rune(cuid[index])) {
+			return index
+		}
+	}
+
+	return -1
+}
+
+// Returns the exact number of possible Cuids of the given length, i.e. 26
+// leading letters times 36 characters for each remaining position, e.g. for
+// capacity planning. Returns zero for lengths below 1.
+func AddressSpace(length int) *big.Int {
+	if length < 1 {
+		return big.NewInt(0)
+	}
+
+	space := new(big.Int).Exp(big.NewInt(36), big.NewInt(int64(length-1)), nil)
+
+	return space.Mul(space, big.NewInt(26))
+}
+
+// Returns the numeric value of a Cuid's body, i.e. every character after the
+// leading letter interpreted as a base36 number.
+//
+// Useful for custom bucketing, hashing or distribution analysis of Cuids
+func BodyValue(cuid string) (*big.Int, error) {
+	if _, err := Parse(cuid); err != nil {
+		return nil, err
+	}
+
+	value, ok := new(big.Int).SetString(cuid[1:], 36)
+	if !ok {
+		return nil, fmt.Errorf("Error: cannot read the body of Cuid (%v) as a base36 number", cuid)
+	}
+
+	return value, nil
+}
+
+// Deterministically derives a Cuid of the given length from an existing Cuid,
+// e.g. to produce a shorter, display-friendly variant.
+//
+// The derivation is lossy and cannot be reversed, but the same input always
+// yields the same output. The first letter of the input is preserved.
+func Shorten(cuid string, length int) (string, error) {
+	if length < MinIdLength || length > MaxIdLength {
+		return "", fmt.Errorf("Error: Can only generate Cuid's with a length between %v and %v", MinIdLength, MaxIdLength)
+	}
+
+	if !IsCuid(cuid) {
+		return "", fmt.Errorf("Error: cannot shorten an invalid Cuid (%v)", cuid)
+	}
+
+	return cuid[:1] + Hash(cuid)[1:length], nil
+}
+
+// A custom function that will generate a random floating-point value between 0 and 1
+//
+// The function is sampled DefaultRandomFuncSamples times, and rejected if any
+// sample is out of range or all samples are identical. Use
+// WithRandomFuncSamples to configure the number of samples, e.g. a single
+// sample for constant functions in tests.
+func WithRandomFunc(randomFunc func() float64) Option {
+	return WithRandomFuncSamples(randomFunc, DefaultRandomFuncSamples)
+}
+
+// A custom function that will generate a random floating-point value between 0
+// and 1, validated by sampling it the given number of times.
+//
+// The function is rejected if any sample is out of range or, when more than
+// one sample is taken, if all samples are identical.
+func WithRandomFuncSamples(randomFunc func() float64, samples int) Option {
+	return func(config *Config) error {
+		if randomFunc == nil {
+			return fmt.Errorf("Error: the provided random function must not be nil")
+		}
+
+		if samples < 1 {
+			return fmt.Errorf("Error: the random function must be sampled at least once")
+		}
+
+		first := randomFunc()
+		allIdentical := true
+
+		for sample := 0; sample < samples; sample++ {
+			randomness := first
+			if sample > 0 {
+				randomness = randomFunc()
+			}
+
+			if randomness < 0 || randomness > 1 {
+				return fmt.Errorf("Error: the provided random function does not generate a value between 0 and 1")
+			}
+
+			allIdentical = allIdentical && randomness == first
+		}
+
+		if samples > 1 && allIdentical {
+			return fmt.Errorf("Error: the provided random function generated the same value for all %v samples", samples)
+		}
+
+		config.RandomFunc = randomFunc
+		config.customRandomFunc = true
+		return nil
+	}
+}
+
+// A custom counter that will be used to affect the entropy of successive id
+// generation calls
+func WithSessionCounter(sessionCounter Counter) Option {
+	return func(config *Config) error {
+		config.SessionCounter = sessionCounter
+		return nil
+	}
+}
+
+// Makes Generate and GenerateFromContext return an empty string rather than
+// panicking when generation fails, e.g. due to a failing entropy source,
+// matching the function returned by Init when an option is invalid.
+//
+// Callers must check for an empty Cuid. GenerateE is unaffected.
+func WithNoPanic() Option {
+	return func(config *Config) error {
+		config.NoPanic = true
+		return nil
+	}
+}
+
+// Configures the length of the generated Cuid
+//
+// Min Length = 2, Max Length = 32
+func WithLength(length int) Option {
+	return func(config *Config) error {
+		if length < MinIdLength || length > MaxIdLength {
+			return fmt.Errorf("Error: Can only generate Cuid's with a length between %v and %v", MinIdLength, MaxIdLength)
+		}
+		config.Length = length
+		return nil
+	}
+}
+
+// Configures the length of the generated Cuid without validating it against
+// MaxIdLength.
+//
+// UNSAFE: this is intended for trusted internal callers that already know the
+// length to be valid. Lengths above MaxIdLength produce Cuids that IsCuid
+// rejects, and cannot be generated with GenerateArray. Prefer WithLength.
+//
+// The other bounds are still enforced: lengths beyond what the hash can encode
+// are rejected with ErrLengthExceedsHashCapacity, and lengths below MinIdLength,
+// or below the minimum length for the configured options, are rejected when the
+// generator is created.
+func WithLengthUnsafe(length int) Option {
+	return func(config *Config) error {
+		if length > hashCapacity {
+			return ErrLengthExceedsHashCapacity
+		}
+		config.Length = length
+		return nil
+	}
+}
+
+// A unique string that will be used by the id generator to help prevent
+// collisions when generating Cuids in a distributed system. An empty string
+// leaves the fingerprint to be derived as usual.
+func WithFingerprint(fingerprint string) Option {
+	return func(config *Config) error {
+		config.Fingerprint = fingerprint
+		return nil
+	}
+}
+
+// Derives the fingerprint from the contents of a file, such as /etc/machine-id,
+// to provide a stable identity across restarts
+func WithFingerprintFromFile(path string) Option {
+	return func(config *Config) error {
+		contents, err := os.ReadFile(path)
+		if err != nil {
+			return fmt.Errorf("Error: could not read fingerprint file: %w", err)
+		}
+
+		if len(strings.TrimSpace(string(contents))) == 0 {
+			return fmt.Errorf("Error: the fingerprint file (%v) is empty", path)
+		}
+
+		config.Fingerprint = Hash(string(contents))[1:]
+		return nil
+	}
+}
+
+// Derives the fingerprint from a function called once during initialization,
+// e.g. to read it from a config service. An error returned by the provider is
+// returned by Init.
+func WithFingerprintProvider(provider func() (string, error)) Option {
+	return func(config *Config) error {
+		if provider == nil {
+			return fmt.Errorf("Error: the fingerprint provider must not be nil")
+		}
+
+		fingerprint, err := provider()
+		if err != nil {
+			return fmt.Errorf("Error: could not provide fingerprint: %w", err)
+		}
+
+		config.Fingerprint = fingerprint
+		return nil
+	}
+}
+
+// Folds the build version of the application, e.g. a release tag or commit
+// hash, into the fingerprint, so that Cuids generated by different versions
+// during a rolling deploy have different fingerprints.
+//
+// The version is hashed with the fingerprint rather than stored, so it cannot
+// be recovered from the Cuid. Composes with the other fingerprint options.
+func WithBuildVersion(version string) Option {
+	return func(config *Config) error {
+		if len(strings.TrimSpace(version)) == 0 {
+			return fmt.Errorf("Error: the build version must not be empty")
+		}
+		config.BuildVersion = version
+		return nil
+	}
+}
+
+// Folds a shard or region identifier into the fingerprint, to eliminate
+// collisions between Cuids generated in different regions of a multi-region
+// deployment.
+//
+// Every shard id in the uint16 range is valid, but each id must be assigned to
+// exactly one shard, e.g. from static deployment config rather than at random,
+// and never reused by another shard.
+func WithShardId(shardId uint16) Option {
+	return func(config *Config) error {
+		if config.HasShardId && config.ShardId != shardId {
+			return fmt.Errorf("Error: a different shard id (%v) has already been configured", config.ShardId)
+		}
+		config.ShardId = shardId
+		config.HasShardId = true
+		return nil
+	}
+}
+
+// Caps how many characters of the fingerprint enter the hash input of each
+// Cuid, an advanced tuning knob for very short ids where the fingerprint's
+// contribution is marginal.
+//
+// By default, the full fingerprint is used
+func WithFingerprintInfluence(chars int) Option {
+	return func(config *Config) error {
+		if chars <= 0 {
+			return fmt.Errorf("Error: the fingerprint influence must be greater than 0")
+		}
+		config.FingerprintInfluence = chars
+		return nil
+	}
+}
+
+// A function that will be invoked once during initialization with the resolved
+// fingerprint, e.g. to log it so that nodes in a fleet can be correlated with
+// their fingerprints
+func WithFingerprintLogger(logger func(fingerprint string)) Option {
+	return func(config *Config) error {
+		config.FingerprintLogger = logger
+		return nil
+	}
+}
+
+// A function that will be invoked on each generation with the exact string fed
+// into the hash, e.g. to debug mismatches against other cuid2 implementations
+// given the same inputs.
+//
+// This is a debugging tool only, as the input exposes the salt, session count
+// and fingerprint of each Cuid. The observer is called synchronously, so it
+// should be fast. A nil observer is ignored.
+func WithHashInputObserver(observer func(input string)) Option {
+	return func(config *Config) error {
+		config.HashInputObserver = observer
+		return nil
+	}
+}
+
+// Lowercases and trims surrounding whitespace from the fingerprint before use,
+// so that fingerprints such as "Node-1 " and "node-1" yield identical
+// generators, preventing subtle config drift across nodes
+func WithNormalizedFingerprint() Option {
+	return func(config *Config) error {
+		config.NormalizeFingerprint = true
+		return nil
+	}
+}
+
+// Restricts the environment variables used to derive the fingerprint to the
+// given set of names, rather than all environment variables.
+//
+// This is useful on platforms such as serverless runtimes, where the set of
+// environment variables can change between invocations. Only variable names
+// are used, never their values. Note that the fingerprint also contains random
+// entropy, so this only stabilizes its environment-derived component.
+//
+// The fingerprint is derived when the generator is created, using the
+// configured random function, and cannot be combined with an explicitly
+// provided fingerprint, e.g. one set by WithFingerprint.
+func WithStableFingerprintKeys(keys ...string) Option {
+	return func(config *Config) error {
+		if len(keys) == 0 {
+			return fmt.Errorf("Error: at least one stable fingerprint key must be provided")
+		}
+		config.FingerprintEnvironmentKeys = keys
+		return nil
+	}
+}
+
+// A function that will be invoked once when the session counter crosses
+// MaxSessionCount, e.g. to trigger an alert or fingerprint rotation in
+// extremely high-volume generators
+func WithCounterOverflowHandler(handler func()) Option {
+	return func(config *Config) error {
+		config.CounterOverflowHandler = handler
+		return nil
+	}
+}
+
+// Regenerates any Cuid that is equal to one of the given ids, e.g. to avoid
+// clashes with reserved identifiers such as routes.
+//
+// A random Cuid of the default length is practically never a given id, but
+// short Cuids can be. After MaxForbiddenIDRetries retries, generation fails
+// rather than returning a forbidden id, which for a set of k ids of length n
+// happens with a probability of roughly (k / AddressSpace(n))^11. The set is
+// copied, so later changes to it have no effect.
+func WithForbiddenIDs(set map[string]struct{}) Option {
+	return func(config *Config) error {
+		forbiddenIDs := make(map[string]struct{}, len(set))
+		for id := range set {
+			forbiddenIDs[id] = struct{}{}
+		}
+		config.ForbiddenIDs = forbiddenIDs
+		return nil
+	}
+}
+
+// Regenerates any Cuid that contains one of the given words, e.g. to avoid
+// profane or confusing substrings in user-visible ids.
+//
+// Each rejection costs an additional generation call. After
+// MaxRejectedWordRetries retries, the last generated Cuid is returned and a
+// warning is logged.
+func WithRejectWords(words []string) Option {
+	return func(config *Config) error {
+		rejectedWords := []string{}
+		for _, word := range words {
+			if len(word) == 0 {
+				return fmt.Errorf("Error: rejected words must not be empty")
+			}
+			rejectedWords = append(rejectedWords, strings.ToLower(word))
+		}
+		config.RejectedWords = rejectedWords
+		return nil
+	}
+}
+
+// A custom source of random bytes for the entropy of each Cuid, e.g. a hardware
+// random number generator, read through the entropy pool.
+//
+// Read failures are returned by GenerateE, while Generate panics. Like the
+// entropy pool, the reader is bypassed when a custom random function is
+// provided.
+func WithEntropyReader(reader io.Reader) Option {
+	return func(config *Config) error {
+		if reader == nil {
+			return fmt.Errorf("Error: the provided entropy reader must not be nil")
+		}
+		config.EntropyReader = reader
+		return nil
+	}
+}
+
+// Batches random draws for the entropy of each Cuid by reading bufSize bytes at
+// a time from crypto/rand, rather than calling the random function once per
+// character.
+//
+// Each random byte is used at most once. The pool is bypassed when a custom
+// random function is provided, so that deterministic generation is unaffected.
+func WithEntropyPool(bufSize int) Option {
+	return func(config *Config) error {
+		if bufSize <= 0 {
+			return fmt.Errorf("Error: the entropy pool size must be greater than 0")
+		}
+		config.EntropyPoolSize = bufSize
+		return nil
+	}
+}
+
+// Decouples the number of random characters in the salt that enters the hash
+// input from the length of generated Cuids, e.g. to oversample entropy for
+// short Cuids.
+//
+// By default, the salt is as long as the Cuid
+func WithEntropyLength(chars int) Option {
+	return func(config *Config) error {
+		if chars <= 0 {
+			return fmt.Errorf("Error: the entropy length must be greater than 0")
+		}
+		config.EntropyLength = chars
+		return nil
+	}
+}
+
+// Guarantees that the salt that enters the hash input has at least the given
+// number of random characters, regardless of the length of generated Cuids,
+// e.g. to avoid weak hash inputs for very short Cuids.
+//
+// By default, the salt is as long as the Cuid, or as configured with
+// WithEntropyLength, so this only takes effect when the minimum exceeds that
+// length. An explicit entropy length below the minimum is rejected.
+func WithMinEntropyLength(chars int) Option {
+	return func(config *Config) error {
+		if chars <= 0 {
+			return fmt.Errorf("Error: the minimum entropy length must be greater than 0")
+		}
+		config.MinEntropyLength = chars
+		return nil
+	}
+}
+
+// Fixes the number of base36 characters of the session count that enter the
+// hash input, zero-padding shorter counts and truncating longer counts to
+// their least significant characters.
+//
+// By default, the full session count is used
+func WithCounterWidth(width int) Option {
+	return func(config *Config) error {
+		if width <= 0 {
+			return fmt.Errorf("Error: the counter width must be greater than 0")
+		}
+		config.CounterWidth = width
+		return nil
+	}
+}
+
+// A custom clock that will be used to compute the timestamp component of each
+// Cuid, e.g. a fake clock shared across components in tests.
+//
+// Since a Clock has millisecond precision, time resolutions finer than a
+// millisecond have no effect.
+func WithClock(clock Clock) Option {
+	return func(config *Config) error {
+		if clock == nil {
+			return fmt.Errorf("Error: the provided clock must not be nil")
+		}
+		config.TimeFunc = func() time.Time {
+			return time.UnixMilli(clock.NowMillis())
+		}
+		return nil
+	}
+}
+
+// Bounds how long a call to GenerateE may take, e.g. to protect request
+// handlers from a slow custom random function.
+//
+// When exceeded, GenerateE returns ErrGenerationTimeout. The timed out
+// generation cannot be cancelled, so its goroutine keeps running until the
+// random function returns, still advancing the session counter, and such
+// goroutines accumulate for as long as the random function stays slow.
+// Generate and the function returned by Init are not affected. Cannot be
+// combined with WithChainedGeneration, as a timed out generation would still
+// extend the chain with a Cuid that no caller received.
+func WithGenerationTimeout(timeout time.Duration) Option {
+	return func(config *Config) error {
+		if timeout <= 0 {
+			return fmt.Errorf("Error: the generation timeout must be greater than 0")
+		}
+		config.GenerationTimeout = timeout
+		return nil
+	}
+}
+
+// Configures the granularity of the timestamp component of each Cuid, e.g.
+// time.Second for coarser or time.Microsecond for finer timestamps.
+//
+// Defaults to time.Millisecond
+func WithTimeResolution(resolution time.Duration) Option {
+	return func(config *Config) error {
+		if resolution <= 0 {
+			return fmt.Errorf("Error: the time resolution must be greater than 0")
+		}
+		config.TimeResolution = resolution
+		return nil
+	}
+}
+
+// Adds a correction offset to the time of each Cuid, e.g. to align the
+// timestamps of a node with known clock skew with the rest of a cluster. The
+// offset may be negative, and also applies to the time encoded by the sortable
+// and date bucket options.
+//
+// Defaults to zero
+func WithTimeOffset(offset time.Duration) Option {
+	return func(config *Config) error {
+		config.TimeOffset = offset
+		return nil
+	}
+}
+
+// Periodically re-salts the fingerprint at the given interval to reduce
+// correlation between Cuids over the lifetime of a long-running generator.
+//
+// Each rotated fingerprint is derived from the resolved fingerprint the
+// generator was created with, e.g. one set by WithFingerprint or a tenant's
+// fingerprint, and fresh randomness, without re-reading the environment.
+// Fingerprint markers are derived from the unrotated fingerprint, so they
+// still match it after rotation.
+//
+// Rotation happens on a background goroutine, which must be stopped by calling
+// Close on the Generator to avoid leaking it. As such, this option should be
+// used with NewGenerator rather than Init. Rotation does not affect the global
+// uniqueness of generated Cuids.
+func WithFingerprintRotation(interval time.Duration) Option {
+	return func(config *Config) error {
+		if interval <= 0 {
+			return fmt.Errorf("Error: the fingerprint rotation interval must be greater than 0")
+		}
+		config.FingerprintRotationInterval = interval
+		return nil
+	}
+}
+
+// A custom function that returns the current time, used to compute the
+// timestamp component of each Cuid
+func WithTimeFunc(timeFunc func() time.Time) Option {
+	return func(config *Config) error {
+		if timeFunc == nil {
+			return fmt.Errorf("Error: the provided time function must not be nil")
+		}
+		config.TimeFunc = timeFunc
+		return nil
+	}
+}
+
+// Generates a random base36 string of the given length using the provided
+// random function, or the default random function if none is provided
+func Entropy(length int, randomFunc func() float64) string {
+	if randomFunc == nil {
+		randomFunc = rand.Float64
+	}
+
+	return createEntropy(length, randomFunc)
+}
+
+// Derives a fingerprint from random entropy and the names of all environment
+// variables
+func createDefaultFingerprint() string {
+	return createFingerprint(rand.Float64, getEnvironmentKeyString())
+}
+
+// Derives the fingerprint used when no option provided one, leaving out the
+// environment variables when the config disallows them. Only reproducible
+// generators draw it from the configured random function.
+func createConfigFingerprint(config *Config) string {
+	if config.randomFingerprint {
+		return createFingerprint(config.RandomFunc, "")
+	}
+
+	if config.EnvironmentFingerprintDisallowed {
+		return createFingerprint(rand.Float64, "")
+	}
+
+	return createDefaultFingerprint()
+}
+
+// Checks whether the fingerprint provided by the options is derived from the
+// environment variables, which is the case for stable fingerprint keys and the
+// shared fingerprint
+func usesEnvironmentFingerprint(config *Config) bool {
+	return len(config.FingerprintEnvironmentKeys) > 0 || isSharedFingerprint(config.Fingerprint)
+}
+
+// Re-salts a resolved fingerprint with fresh randomness
+func createRotatedFingerprint(baseFingerprint string, randomFunc func() float64) string {
+	return Hash(baseFingerprint + "rotation" + createEntropy(MaxIdLength, randomFunc))[1:]
+}
+
+func createFingerprint(randomFunc func() float64, envKeyString string) string {
+	sourceString := createEntropy(MaxIdLength, randomFunc)
+
+	if len(envKeyString) > 0 {
+		sourceString += envKeyString
+	}
+
+	sourceStringHash := Hash(sourceString)
+
+	return sourceStringHash[1:]
+}
+
+// Reusable buffers for building entropy strings, to avoid allocating on every
+// appended character
+var entropyBufferPool = sync.Pool{
+	New: func() any {
+		buffer := make([]byte, 0, MaxIdLength)
+		return &buffer
+	},
+}
+
+func createEntropy(length int, randomFunc func() float64) string {
+	buffer := entropyBufferPool.Get().(*[]byte)
+	entropy := (*buffer)[:0]
+
+	for len(entropy) < length {
+		randomness := int64(math.Floor(randomFunc() * 36))
+		entropy = strconv.AppendInt(entropy, randomness, 36)
+	}
+
+	result := string(entropy)
+	*buffer = entropy
+	entropyBufferPool.Put(buffer)
+
+	return result
+}
+
+var (
+	environmentCacheMutex sync.Mutex
+	environmentCache      = map[string]string{}
+)
+
+// Clears the cached environment variable names used to derive fingerprints,
+// so that generators created afterwards observe changes to the environment,
+// e.g. in tests that set environment variables.
+func InvalidateEnvironmentCache() {
+	environmentCacheMutex.Lock()
+	defer environmentCacheMutex.Unlock()
+
+	environmentCache = map[string]string{}
+}
+
+// Returns the names of all environment variables, or only those within the
+// provided set of stable keys if any are given.
+//
+// Since the environment rarely changes, the result is cached until
+// InvalidateEnvironmentCache is called
+func getEnvironmentKeyString(stableKeys ...string) string {
+	cacheKey := strings.Join(stableKeys, "=")
+
+	environmentCacheMutex.Lock()
+	defer environmentCacheMutex.Unlock()
+
+	if envKeyString, cached := environmentCache[cacheKey]; cached {
+		return envKeyString
+	}
+
+	envKeyString := readEnvironmentKeyString(stableKeys...)
+	environmentCache[cacheKey] = envKeyString
+
+	return envKeyString
+}
+
+func readEnvironmentKeyString(stableKeys ...string) string {
+	env := os.Environ()
+
+	keys := []string{}
+
+	allowedKeys := map[string]struct{}{}
+	for _, key := range stableKeys {
+		allowedKeys[key] = struct{}{}
+	}
+
+	// Discard values of environment variables
+	for _, variable := range env {
+		key := variable[:strings.IndexByte(variable, '=')]
+		if _, allowed := allowedKeys[key]; len(allowedKeys) > 0 && !allowed {
+			continue
+		}
+		keys = append(keys, key)
+	}
+
+	// Ensure a stable ordering regardless of how the environment is enumerated
+	if len(allowedKeys) > 0 {
+		sort.Strings(keys)
+	}
+
+	return strings.Join(keys, "")
+}
+
+// Hashes the input with SHA3-512 and encodes the digest in base36, e.g. for
+// deriving other identifiers that are compatible with Cuids.
+//
+// The leading character of the encoded digest is dropped, as it can only take
+// a few values and would skew the distribution of the output. The remaining
+// characters are lowercase letters and digits, so the result may start with a
+// digit and is not itself a valid Cuid.
+func Hash(input string) string {
+	hash := sha3.New512()
+	hash.Write([]byte(input))
+	hashDigest := hash.Sum(nil)
+	return new(big.Int).SetBytes(hashDigest).Text(36)[1:]
+}
+
+// Encodes the session count in base36, zero-padded or truncated to keep its
+// least significant characters when a fixed width is given
+func formatCount(count int64, width int) string {
+	encodedCount := strconv.FormatInt(count, 36)
+
+	if width <= 0 {
+		return encodedCount
+	}
+
+	if len(encodedCount) > width {
+		return encodedCount[len(encodedCount)-width:]
+	}
+
+	return strings.Repeat("0", width-len(encodedCount)) + encodedCount
+}
+
+// Returns the given time as a count of resolution-sized units since the Unix
+// epoch
+func getTimestamp(t time.Time, resolution time.Duration) int64 {
+	if resolution == time.Millisecond {
+		return t.UnixMilli()
+	}
+
+	return t.UnixNano() / int64(resolution)
 }
 
 func getRandomAlphabet(randomFunc func() float64) string {
-	alphabets := "abcdefghijklmnopqrstuvwxyz"
-	randomIndex := int64(math.Floor(randomFunc() * 26))
-	randomAlphabet := string(alphabets[randomIndex])
+	randomIndex := int64(math.Floor(randomFunc() * float64(len(Alphabet))))
+	randomAlphabet := string(Alphabet[randomIndex])
 	return randomAlphabet
 }
@@ -1,8 +1,18 @@
 package cuid2
 
 import (
+	"context"
+	"errors"
 	"math/rand"
+	"os"
+	"path/filepath"
+	"runtime"
+	"strings"
+	"sync"
+	"sync/atomic"
 	"testing"
+	"time"
+	"unsafe"
 )
 
 // External Tests
@@ -25,6 +35,276 @@ func TestIsCuid(t *testing.T) {
 	}
 }
 
+func TestIsCuidWithAdversarialInputs(t *testing.T) {
+	megabyte := 1 << 20
+	testCases := map[string]string{
+		strings.Repeat("a", megabyte):        "Long",
+		strings.Repeat("A", megabyte):        "Uppercase",
+		strings.Repeat("\u00e9", megabyte/2): "Unicode",
+		strings.Repeat("a", megabyte) + "!":  "Trailing invalid character",
+		"a" + strings.Repeat("\x00", 30):     "Null bytes",
+		"a\n" + Generate()[2:]:               "Newline",
+	}
+
+	for testCase, description := range testCases {
+		start := time.Now()
+		if IsCuid(testCase) {
+			t.Fatalf("Expected IsCuid to reject input (%v)", description)
+		}
+
+		if elapsed := time.Since(start); elapsed > time.Second {
+			t.Fatalf("Expected IsCuid to reject input (%v) in linear time, but took %v", description, elapsed)
+		}
+	}
+}
+
+func TestClassifyInvalid(t *testing.T) {
+	testCases := map[string]InvalidReason{
+		Generate():                           Valid,        // Default
+		Generate() + Generate() + Generate(): TooLong,      // Too Long
+		"":                                   TooShort,     // Too Short
+		"42":                                 BadFirstChar, // Non-CUID
+		"aaaaDLL":                            BadBodyChar,  // Capital letters
+		"yi7rqj1trke":                        Valid,        // Valid
+		"-x!ha":                              BadFirstChar, // Invalid characters
+		"ab*%@#x":                            BadBodyChar,  // Invalid characters
+	}
+
+	for testCase, expected := range testCases {
+		if actual := ClassifyInvalid(testCase); actual != expected {
+			t.Fatalf("Expected ClassifyInvalid(%v) to be %v, but got %v", testCase, expected, actual)
+		}
+
+		if _, err := Parse(testCase); (err == nil) != (expected == Valid) {
+			t.Fatalf("Expected Parse(%v) to agree with ClassifyInvalid, but got error = %v", testCase, err)
+		}
+	}
+}
+
+func TestWarmup(t *testing.T) {
+	Warmup()
+	first := Generate()
+	Warmup()
+
+	if !IsCuid(first) || !IsCuid(Generate()) {
+		t.Fatalf("Expected to generate valid Cuids around calls to Warmup")
+	}
+}
+
+func TestConcurrentGeneration(t *testing.T) {
+	numWorkers := 16
+	idsPerWorker := 100000 / numWorkers
+
+	results := make(chan []string, numWorkers)
+	for w := 0; w < numWorkers; w++ {
+		go func() {
+			ids := make([]string, 0, idsPerWorker)
+			for i := 0; i < idsPerWorker; i++ {
+				ids = append(ids, Generate())
+			}
+			results <- ids
+		}()
+	}
+
+	set := map[string]struct{}{}
+	for w := 0; w < numWorkers; w++ {
+		for _, id := range <-results {
+			if _, exists := set[id]; exists {
+				t.Fatalf("Collision detected for Cuid (%v) during concurrent generation", id)
+			}
+			set[id] = struct{}{}
+		}
+	}
+}
+
+func TestIsDNSLabelCuid(t *testing.T) {
+	testCases := map[string]bool{
+		Generate():                    true,  // Default
+		"usr-" + Generate():           true,  // Hyphenated prefix
+		"usr_" + Generate():           false, // Underscore
+		"a" + strings.Repeat("0", 62): true,  // Max length
+		"a" + strings.Repeat("0", 63): false, // Too Long
+		"":                            false, // Empty
+		"42":                          false, // Starts with a digit
+		"abc-":                        false, // Ends with a hyphen
+		"aaaaDLL":                     false, // Capital letters
+	}
+
+	for testCase, expected := range testCases {
+		if IsDNSLabelCuid(testCase) != expected {
+			t.Fatalf("Expected IsDNSLabelCuid(%v) to be %v, but got %v", testCase, expected, !expected)
+		}
+	}
+}
+
+func TestForbiddenIDs(t *testing.T) {
+	generator, _ := NewReproducible(42, WithLength(4))
+	forbidden := generator.Generate()
+
+	set := map[string]struct{}{forbidden: {}}
+	filtered, err := NewReproducible(42, WithLength(4), WithForbiddenIDs(set))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	delete(set, forbidden)
+
+	if cuid := filtered.Generate(); cuid == forbidden || !IsCuid(cuid) {
+		t.Fatalf("Expected the forbidden id (%v) to be regenerated, but got %v", forbidden, cuid)
+	}
+
+	constant, _ := NewGenerator(
+		WithLength(4),
+		WithSessionCounter(plainCounter{}),
+		WithRandomFuncSamples(func() float64 { return 0.5 }, 1),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(0) }),
+		WithFingerprint("node-1"),
+	)
+	repeated := constant.Generate()
+
+	exhausted, _ := NewGenerator(
+		WithLength(4),
+		WithSessionCounter(plainCounter{}),
+		WithRandomFuncSamples(func() float64 { return 0.5 }, 1),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(0) }),
+		WithFingerprint("node-1"),
+		WithForbiddenIDs(map[string]struct{}{repeated: {}}),
+	)
+	if _, err := exhausted.GenerateE(); err == nil {
+		t.Fatalf("Expected to receive an error when only forbidden ids can be generated, but got nothing")
+	}
+}
+
+func TestAddressSpace(t *testing.T) {
+	testCases := map[int]string{
+		0:  "0",
+		1:  "26",
+		2:  "936",
+		8:  "2037468266496",
+		24: "16215519455311624673396319144961376256",
+		32: "45745762589925145010006096128253029218258555764736",
+	}
+
+	for length, expected := range testCases {
+		if actual := AddressSpace(length).String(); actual != expected {
+			t.Fatalf("Expected AddressSpace(%v) to be %v, but got %v", length, expected, actual)
+		}
+	}
+}
+
+func TestIsFilesystemSafe(t *testing.T) {
+	testCases := map[string]bool{
+		Generate():                     true,  // Default
+		"usr_" + Generate():            true,  // Underscore prefix
+		"usr-" + Generate():            true,  // Hyphenated prefix
+		Generate() + ".json":           true,  // Extension
+		"usr/" + Generate():            false, // Path separator
+		"usr:" + Generate():            false, // Colon
+		"Usr_" + Generate():            false, // Capital letters
+		"." + Generate():               false, // Hidden file
+		"-" + Generate():               false, // Starts with a hyphen
+		Generate() + ".":               false, // Ends with a dot
+		"con":                          false, // Reserved name
+		"a" + strings.Repeat("0", 254): true,  // Max length
+		"a" + strings.Repeat("0", 255): false, // Too long
+		"":                             false, // Empty
+	}
+
+	for testCase, expected := range testCases {
+		if IsFilesystemSafe(testCase) != expected {
+			t.Fatalf("Expected IsFilesystemSafe(%v) to be %v, but got %v", testCase, expected, !expected)
+		}
+	}
+}
+
+func TestCuidChecker(t *testing.T) {
+	check := NewCuidChecker(1, 2)
+
+	testCases := map[string]bool{
+		"a":   true,  // Single character
+		"ab":  true,  // At max bound
+		"abc": false, // Above max bound
+		"1a":  false, // Non-CUID
+		"":    false, // Empty
+	}
+
+	for testCase, expected := range testCases {
+		if check(testCase) != expected || IsCuidWithBounds(testCase, 1, 2) != expected {
+			t.Fatalf("Expected checker(%v) to be %v, but got %v", testCase, expected, !expected)
+		}
+	}
+}
+
+func TestAreNearDuplicates(t *testing.T) {
+	testCases := []struct {
+		a           string
+		b           string
+		maxDistance int
+		expected    bool
+	}{
+		{"yi7rqj1trke", "yi7rqj1trke", 0, true},   // Identical
+		{"yi7rqj1trke", "yi7rqj1trkf", 1, true},   // One substitution
+		{"yi7rqj1trke", "yl7rqj1trkf", 1, false},  // Two substitutions
+		{"yi7rqj1trke", "yl7rqj1trkf", 2, true},   // Two substitutions
+		{"yi7rqj1trke", "yi7rqj1trk", 2, false},   // Unequal length
+		{"yi7rqj1trke", "yi7rqj1trke", -1, false}, // Negative distance
+	}
+
+	for _, testCase := range testCases {
+		actual := AreNearDuplicates(testCase.a, testCase.b, testCase.maxDistance)
+		if actual != testCase.expected {
+			t.Fatalf("Expected AreNearDuplicates(%v, %v, %v) to be %v, but got %v", testCase.a, testCase.b, testCase.maxDistance, testCase.expected, actual)
+		}
+	}
+}
+
+func TestFilterInvalid(t *testing.T) {
+	valid := Generate()
+	ids := []string{"42", valid, "aaaaDLL", "yi7rqj1trke", ""}
+
+	invalid := FilterInvalid(ids)
+	expected := []string{"42", "aaaaDLL", ""}
+
+	if len(invalid) != len(expected) {
+		t.Fatalf("Expected %v invalid ids, but got %v", len(expected), invalid)
+	}
+
+	for index := range expected {
+		if invalid[index] != expected[index] {
+			t.Fatalf("Expected invalid id %v to be %q, but got %q", index, expected[index], invalid[index])
+		}
+	}
+
+	if invalid := FilterInvalid([]string{valid}); len(invalid) != 0 {
+		t.Fatalf("Expected no invalid ids, but got %v", invalid)
+	}
+}
+
+func TestIsCuidWithBounds(t *testing.T) {
+	testCases := []struct {
+		cuid     string
+		min      int
+		max      int
+		expected bool
+	}{
+		{"a", 1, MaxIdLength, true},            // Single character sentinel
+		{"a", MinIdLength, MaxIdLength, false}, // Below default min
+		{"ab", 1, 2, true},                     // At max bound
+		{"abc", 1, 2, false},                   // Above max bound
+		{"1a", 1, MaxIdLength, false},          // Non-CUID
+		{"", 0, MaxIdLength, false},            // Empty
+	}
+
+	for _, testCase := range testCases {
+		actual := IsCuidWithBounds(testCase.cuid, testCase.min, testCase.max)
+		if actual != testCase.expected {
+			t.Fatalf(
+				"Expected IsCuidWithBounds(%v, %v, %v) to be %v, but got %v",
+				testCase.cuid, testCase.min, testCase.max, testCase.expected, actual,
+			)
+		}
+	}
+}
+
 func TestGeneratingInvalidCuid(t *testing.T) {
 	_, err := Init(WithLength(64))
 	if err == nil {
@@ -34,6 +314,39 @@ func TestGeneratingInvalidCuid(t *testing.T) {
 	}
 }
 
+func TestGeneratingCuidWithUnsafeLength(t *testing.T) {
+	customLength := 12
+	generate, err := Init(WithLengthUnsafe(customLength))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuid := generate()
+
+	if len(cuid) != customLength {
+		t.Fatalf("Expected to generate Cuid with a custom length of %v, but got %v", customLength, len(cuid))
+	}
+}
+
+func TestGeneratingCuidBeyondHashCapacity(t *testing.T) {
+	generate, err := Init(WithLengthUnsafe(hashCapacity))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if cuid := generate(); len(cuid) != hashCapacity {
+		t.Fatalf("Expected to generate Cuid with a length of %v, but got %v", hashCapacity, len(cuid))
+	}
+
+	if _, err := Init(WithLengthUnsafe(hashCapacity + 1)); !errors.Is(err, ErrLengthExceedsHashCapacity) {
+		t.Fatalf("Expected to receive ErrLengthExceedsHashCapacity, but got %v", err)
+	}
+
+	if _, err := Init(WithLengthUnsafe(MinIdLength - 1)); err == nil {
+		t.Fatalf("Expected to receive an error for a length below MinIdLength, but got nothing")
+	}
+}
+
 func TestDefaultCuidLength(t *testing.T) {
 	cuid := Generate()
 	if len(cuid) != DefaultIdLength {
@@ -68,33 +381,1345 @@ func TestGeneratingCuidWithMaxLength(t *testing.T) {
 	}
 }
 
-// Internal Tests
-func TestSessionCounter(t *testing.T) {
-	var initialSessionCount int64 = 10
-	sessionCounter := NewSessionCounter(initialSessionCount)
-	expectedCounts := []int64{11, 12, 13, 14}
-	actualCounts := []int64{
-		sessionCounter.Increment(),
-		sessionCounter.Increment(),
-		sessionCounter.Increment(),
-		sessionCounter.Increment(),
+func TestReproducibleGeneration(t *testing.T) {
+	var seed int64 = 42
+
+	first, err := NewReproducible(seed)
+	if err != nil {
+		t.Fatalf("Expected to initialize reproducible generator but received error = %v", err.Error())
 	}
 
-	for index, actualCount := range actualCounts {
-		expectedCount := expectedCounts[index]
-		if actualCount != expectedCount {
-			t.Error("Expected session counts to increment by one for each successive call")
-			t.Errorf("For an initial session count of %v, expected %v", initialSessionCount, expectedCounts)
-			t.Fatalf("Got %v", actualCounts)
+	second, err := NewReproducible(seed)
+	if err != nil {
+		t.Fatalf("Expected to initialize reproducible generator but received error = %v", err.Error())
+	}
+
+	for i := 0; i < 100; i++ {
+		expected := first.Generate()
+		actual := second.Generate()
+		if expected != actual {
+			t.Fatalf("Expected call %v of both generators to yield %v, but got %v", i, expected, actual)
 		}
 	}
+
+	other, err := NewReproducible(seed + 1)
+	if err != nil {
+		t.Fatalf("Expected to initialize reproducible generator but received error = %v", err.Error())
+	}
+
+	if other.Generate() == first.Generate() {
+		t.Fatalf("Expected generators with different seeds to yield different Cuids")
+	}
 }
 
-func TestCreatingFingerprintWithEnvKeyString(t *testing.T) {
-	fingerprint := createFingerprint(rand.Float64, getEnvironmentKeyString())
-	if len(fingerprint) < MinIdLength {
-		t.Error("Could not generate fingerprint of adequate length")
-		t.Fatalf("Expected length to be at least %v, but got %v", MinIdLength, len(fingerprint))
+func TestGeneratingFixedCuid(t *testing.T) {
+	customLength := 16
+	generator, err := NewGenerator(WithLength(customLength))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	fixedCuid := generator.GenerateArray()
+
+	if fixedCuid.Len() != customLength {
+		t.Fatalf("Expected fixed Cuid to have a length of %v, but got %v", customLength, fixedCuid.Len())
+	}
+
+	if !IsCuid(fixedCuid.String()) {
+		t.Fatalf("Expected fixed Cuid to convert back to a valid Cuid, but got %v", fixedCuid.String())
+	}
+
+	for _, char := range fixedCuid[customLength:] {
+		if char != 0 {
+			t.Fatalf("Expected fixed Cuid to be zero-padded, but got %v", fixedCuid)
+		}
+	}
+
+	oversized, err := NewGenerator(WithLengthUnsafe(MaxIdLength+1), WithNoPanic())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if fixedCuid := oversized.GenerateArray(); fixedCuid.Len() != 0 {
+		t.Fatalf("Expected a zero-valued fixed Cuid for a length above MaxIdLength, but got %v", fixedCuid.String())
+	}
+
+	panicking, _ := NewGenerator(WithLengthUnsafe(MaxIdLength + 1))
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected GenerateArray to panic for a length above MaxIdLength")
+		}
+	}()
+	panicking.GenerateArray()
+}
+
+func TestFingerprintRotation(t *testing.T) {
+	generator, err := NewGenerator(WithFingerprintRotation(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	defer generator.Close()
+
+	initialFingerprint := generator.fingerprint.Load().(string)
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if cuid := generator.Generate(); !IsCuid(cuid) {
+					t.Errorf("Expected to generate a valid Cuid during rotation, but got %v", cuid)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	time.Sleep(10 * time.Millisecond)
+
+	if generator.fingerprint.Load().(string) == initialFingerprint {
+		t.Fatalf("Expected fingerprint to be rotated, but it was unchanged")
+	}
+
+	generator.Close()
+	generator.Close()
+}
+
+func TestFingerprintRotationKeepsConfiguredFingerprint(t *testing.T) {
+	generator, err := NewGenerator(
+		WithFingerprint("node-1"),
+		WithFingerprintMarker(),
+		WithFingerprintRotation(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	defer generator.Close()
+
+	time.Sleep(10 * time.Millisecond)
+
+	if generator.Config().Fingerprint == "node-1" {
+		t.Fatalf("Expected fingerprint to be rotated, but it was unchanged")
+	}
+
+	if cuid := generator.Generate(); !FingerprintMatches(cuid, "node-1") {
+		t.Fatalf("Expected Cuid (%v) to match the configured fingerprint after rotation", cuid)
+	}
+
+	constant := func() float64 { return 0.5 }
+	if createRotatedFingerprint("node-1", constant) == createRotatedFingerprint("node-2", constant) {
+		t.Fatalf("Expected rotated fingerprints to be derived from the configured fingerprint")
+	}
+}
+
+// Exercises concurrent generation across rotations with each of the provided
+// counters, and should be run with the race detector
+func TestFingerprintRotationWithCounters(t *testing.T) {
+	for _, counterType := range []CounterType{AtomicCounterType, PaddedCounterType, ClockCounterType} {
+		generator, err := NewGenerator(
+			WithFingerprintRotation(time.Millisecond),
+			WithCounterType(counterType),
+		)
+		if err != nil {
+			t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+		}
+
+		initialCount, _ := generator.ExportCounter()
+		numWorkers, cuidsPerWorker := 8, 2000
+
+		wg := new(sync.WaitGroup)
+		for i := 0; i < numWorkers; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < cuidsPerWorker; j++ {
+					if cuid := generator.Generate(); !IsCuid(cuid) {
+						t.Errorf("Expected to generate a valid Cuid during rotation, but got %v", cuid)
+						return
+					}
+				}
+			}()
+		}
+		wg.Wait()
+		generator.Close()
+
+		finalCount, _ := generator.ExportCounter()
+		if finalCount-initialCount < int64(numWorkers*cuidsPerWorker) {
+			t.Fatalf("Expected counter type %v to advance by at least %v across rotations, but advanced by %v", counterType, numWorkers*cuidsPerWorker, finalCount-initialCount)
+		}
+	}
+}
+
+func TestEntropy(t *testing.T) {
+	for _, length := range []int{0, 1, MinIdLength, DefaultIdLength, MaxIdLength} {
+		entropy := Entropy(length, nil)
+		if len(entropy) != length {
+			t.Fatalf("Expected entropy to have a length of %v, but got %v", length, len(entropy))
+		}
+
+		for _, char := range entropy {
+			if !strings.ContainsRune("0123456789abcdefghijklmnopqrstuvwxyz", char) {
+				t.Fatalf("Expected entropy to only contain base36 characters, but got %v", entropy)
+			}
+		}
+	}
+}
+
+func TestCounterOverflowHandler(t *testing.T) {
+	var invocations int64
+
+	generator, err := NewGenerator(
+		WithSessionCounter(NewSessionCounter(MaxSessionCount-100)),
+		WithCounterOverflowHandler(func() {
+			atomic.AddInt64(&invocations, 1)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 100; j++ {
+				generator.Generate()
+			}
+		}()
+	}
+	wg.Wait()
+
+	if invocations != 1 {
+		t.Fatalf("Expected counter overflow handler to be invoked once, but got %v", invocations)
+	}
+}
+
+func TestCounterOverflowHandlerFiresOnceUnderContention(t *testing.T) {
+	for trial := 0; trial < 2000; trial++ {
+		var invocations int64
+
+		counter := NewSessionCounter(MaxSessionCount - 100)
+		generator, _ := NewGenerator(
+			WithSessionCounter(counter),
+			WithCounterOverflowHandler(func() {
+				atomic.AddInt64(&invocations, 1)
+			}),
+		)
+
+		wg := new(sync.WaitGroup)
+		for i := 0; i < 4; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					sessionCount := counter.Increment()
+					// Widens the window in which counts are observed out of order
+					runtime.Gosched()
+					generator.checkCounterOverflow(sessionCount)
+				}
+			}()
+		}
+		wg.Wait()
+
+		if invocations != 1 {
+			t.Fatalf("Expected counter overflow handler to be invoked once in trial %v, but got %v", trial, invocations)
+		}
+	}
+}
+
+type wrappingCounter struct {
+	counts []int64
+	index  int
+}
+
+func (c *wrappingCounter) Increment() int64 {
+	count := c.counts[c.index%len(c.counts)]
+	c.index++
+	return count
+}
+
+func TestCounterOverflowHandlerRearmsOnWrap(t *testing.T) {
+	invocations := 0
+
+	generator, _ := NewGenerator(
+		WithSessionCounter(&wrappingCounter{
+			counts: []int64{MaxSessionCount, MaxSessionCount + 1, MaxSessionCount - 1, MaxSessionCount + 2, 0, 1},
+		}),
+		WithCounterOverflowHandler(func() {
+			invocations++
+		}),
+	)
+
+	for i := 0; i < 6; i++ {
+		generator.Generate()
+	}
+
+	if invocations != 1 {
+		t.Fatalf("Expected counter overflow handler to ignore counts observed out of order, but it was invoked %v times", invocations)
+	}
+
+	for i := 0; i < 6; i++ {
+		generator.Generate()
+	}
+
+	if invocations != 2 {
+		t.Fatalf("Expected counter overflow handler to fire again after the counter wrapped around, but it was invoked %v times", invocations)
+	}
+}
+
+func TestHash(t *testing.T) {
+	digest := Hash("input")
+
+	if Hash("input") != digest {
+		t.Fatalf("Expected hashing the same input to yield the same digest")
+	}
+
+	if Hash("other") == digest {
+		t.Fatalf("Expected hashing different inputs to yield different digests")
+	}
+
+	if len(digest) < hashCapacity {
+		t.Fatalf("Expected digest to have at least %v characters, but got %v", hashCapacity, len(digest))
+	}
+
+	for _, char := range digest {
+		if !strings.ContainsRune(Base36Digits, char) {
+			t.Fatalf("Expected digest to be base36 encoded, but got %v", digest)
+		}
+	}
+}
+
+func TestShorten(t *testing.T) {
+	cuid := Generate()
+
+	shortened, err := Shorten(cuid, 12)
+	if err != nil {
+		t.Fatalf("Expected to shorten Cuid but received error = %v", err.Error())
+	}
+
+	if len(shortened) != 12 || !IsCuid(shortened) {
+		t.Fatalf("Expected to derive a valid Cuid with a length of 12, but got %v", shortened)
+	}
+
+	if again, _ := Shorten(cuid, 12); again != shortened {
+		t.Fatalf("Expected shortening to be deterministic, but got %v and %v", shortened, again)
+	}
+
+	if _, err := Shorten(cuid, MaxIdLength+1); err == nil {
+		t.Fatalf("Expected to receive an error for an out of bounds length, but got nothing")
+	}
+
+	if _, err := Shorten("aaaaDLL", 12); err == nil {
+		t.Fatalf("Expected to receive an error for an invalid Cuid, but got nothing")
+	}
+}
+
+func TestRejectWords(t *testing.T) {
+	generator, err := NewGenerator(WithLength(4), WithRejectWords([]string{"A", "e"}))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	for i := 0; i < 1000; i++ {
+		if cuid := generator.Generate(); strings.ContainsAny(cuid, "ae") {
+			t.Fatalf("Expected Cuid to not contain any rejected words, but got %v", cuid)
+		}
+	}
+
+	if _, err := Init(WithRejectWords([]string{""})); err == nil {
+		t.Fatalf("Expected to receive an error for an empty rejected word, but got nothing")
+	}
+}
+
+func TestConfigSnapshot(t *testing.T) {
+	generator, err := NewGenerator(
+		WithLength(16),
+		WithFingerprint("node-1"),
+		WithRejectWords([]string{"abc"}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	snapshot := generator.Config()
+
+	if snapshot.Length != 16 || snapshot.Fingerprint != "node-1" {
+		t.Fatalf("Expected snapshot to reflect the resolved config, but got %+v", snapshot)
+	}
+
+	if snapshot.CounterType != "*cuid2.SessionCounter" {
+		t.Fatalf("Expected snapshot counter type to be *cuid2.SessionCounter, but got %v", snapshot.CounterType)
+	}
+
+	snapshot.RejectedWords[0] = "xyz"
+	if generator.config.RejectedWords[0] != "abc" {
+		t.Fatalf("Expected snapshot to not expose mutable internal state")
+	}
+}
+
+func TestEntropyLength(t *testing.T) {
+	generator, err := NewGenerator(WithLength(4), WithEntropyLength(64))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if salt, _ := generator.createSalt(); len(salt) != 64 {
+		t.Fatalf("Expected salt to have a length of 64, but got %v", len(salt))
+	}
+
+	if cuid := generator.Generate(); len(cuid) != 4 || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with a length of 4, but got %v", cuid)
+	}
+
+	defaulted, _ := NewReproducible(42, WithLength(8), WithEntropyLength(8))
+	unset, _ := NewReproducible(42, WithLength(8))
+	if defaulted.Generate() != unset.Generate() {
+		t.Fatalf("Expected the entropy length to default to the Cuid length")
+	}
+
+	if _, err := Init(WithEntropyLength(0)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithEntropyLength(0)), but got nothing")
+	}
+}
+
+func TestMinEntropyLength(t *testing.T) {
+	generator, err := NewGenerator(WithLength(4), WithMinEntropyLength(16))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if salt, _ := generator.createSalt(); len(salt) != 16 {
+		t.Fatalf("Expected salt to be raised to a length of 16, but got %v", len(salt))
+	}
+
+	longer, _ := NewGenerator(WithLength(24), WithMinEntropyLength(16))
+	if salt, _ := longer.createSalt(); len(salt) != 24 {
+		t.Fatalf("Expected salt to keep the Cuid length of 24, but got %v", len(salt))
+	}
+
+	if _, err := Init(WithEntropyLength(8), WithMinEntropyLength(16)); err == nil {
+		t.Fatalf("Expected to receive an error for an entropy length below the minimum, but got nothing")
+	}
+
+	if _, err := Init(WithMinEntropyLength(0)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithMinEntropyLength(0)), but got nothing")
+	}
+}
+
+func TestEntropyPool(t *testing.T) {
+	generator, err := NewGenerator(WithEntropyPool(7))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	for i := 0; i < 100; i++ {
+		if cuid := generator.Generate(); len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid using the entropy pool, but got %v", cuid)
+		}
+	}
+
+	pooled, _ := NewReproducible(42, WithEntropyPool(64))
+	unpooled, _ := NewReproducible(42)
+	if pooled.Generate() != unpooled.Generate() {
+		t.Fatalf("Expected the entropy pool to be bypassed for custom random functions")
+	}
+
+	if _, err := Init(WithEntropyPool(0)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithEntropyPool(0)), but got nothing")
+	}
+}
+
+func TestBodyValue(t *testing.T) {
+	value, err := BodyValue("a0000000000000000000000z")
+	if err != nil {
+		t.Fatalf("Expected to read the body value but received error = %v", err.Error())
+	}
+
+	if value.Int64() != 35 {
+		t.Fatalf("Expected body value to be 35, but got %v", value)
+	}
+
+	cuid := Generate()
+	value, _ = BodyValue(cuid)
+	if value.Text(36) != strings.TrimLeft(cuid[1:], "0") {
+		t.Fatalf("Expected body value of %v to round-trip, but got %v", cuid, value.Text(36))
+	}
+
+	if _, err := BodyValue("aaaaDLL"); err == nil {
+		t.Fatalf("Expected to receive an error for an invalid Cuid, but got nothing")
+	}
+}
+
+func TestParse(t *testing.T) {
+	cuid := Generate()
+
+	parsed, err := Parse(cuid)
+	if err != nil {
+		t.Fatalf("Expected to parse Cuid but received error = %v", err.Error())
+	}
+
+	if string(parsed) != cuid {
+		t.Fatalf("Expected parsed Cuid to be %v, but got %v", cuid, parsed)
+	}
+
+	testCases := map[string]string{
+		"a":                     "too short",
+		Generate() + Generate(): "too long",
+		"42":                    "must start with a lowercase letter",
+		"aaaaDLL":               "invalid character 'D' at position 4",
+		"ab*%@#x":               "invalid character '*' at position 2",
+	}
+
+	for testCase, expected := range testCases {
+		_, err := Parse(testCase)
+		if err == nil || !strings.Contains(err.Error(), expected) {
+			t.Fatalf("Expected Parse(%v) to fail with an error containing %q, but got %v", testCase, expected, err)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected MustParse to panic for an invalid Cuid")
+		}
+	}()
+	MustParse("aaaaDLL")
+}
+
+func TestFingerprintMarker(t *testing.T) {
+	generator, err := NewGenerator(WithFingerprint("node-1"), WithFingerprintMarker())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	for i := 0; i < 100; i++ {
+		cuid := generator.Generate()
+		if len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with a fingerprint marker, but got %v", cuid)
+		}
+
+		if !FingerprintMatches(cuid, "node-1") {
+			t.Fatalf("Expected Cuid (%v) to match its generator's fingerprint", cuid)
+		}
+	}
+
+	sortable, err := NewGenerator(WithFingerprintMarker(), WithSortableDescending(), WithLength(MinSortableIdLength))
+	if err == nil {
+		sortable.Close()
+		t.Fatalf("Expected to receive an error for a Cuid too short to hold the marker, but got nothing")
+	}
+}
+
+type fakeClock struct {
+	millis int64
+}
+
+func (c *fakeClock) NowMillis() int64 {
+	return c.millis
+}
+
+func TestClock(t *testing.T) {
+	clock := &fakeClock{millis: 1700000000000}
+
+	first, _ := NewReproducible(42, WithClock(clock))
+	second, _ := NewReproducible(42, WithTimeFunc(func() time.Time { return time.UnixMilli(1700000000000) }))
+	if first.Generate() != second.Generate() {
+		t.Fatalf("Expected a fake clock to drive deterministic output")
+	}
+
+	clock.millis++
+	if first.Generate() == second.Generate() {
+		t.Fatalf("Expected advancing the fake clock to affect the generated Cuid")
+	}
+
+	sortable, err := NewGenerator(WithClock(clock), WithSortableDescending())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if extractedTime, _ := ExtractTime(sortable.Generate()); extractedTime.UnixMilli() != clock.millis {
+		t.Fatalf("Expected extracted time to be %v, but got %v", clock.millis, extractedTime.UnixMilli())
+	}
+
+	if (RealClock{}).NowMillis() <= 0 {
+		t.Fatalf("Expected the real clock to return the current time")
+	}
+
+	if _, err := Init(WithClock(nil)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithClock(nil)), but got nothing")
+	}
+}
+
+func TestRecoverableSequence(t *testing.T) {
+	var initialSessionCount int64 = 41
+
+	generator, err := NewGenerator(
+		WithSessionCounter(NewSessionCounter(initialSessionCount)),
+		WithRecoverableSequence(),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	for expected := initialSessionCount + 1; expected < initialSessionCount+10; expected++ {
+		cuid := generator.Generate()
+		if len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with a recoverable sequence, but got %v", cuid)
+		}
+
+		sequence, err := SequenceOf(cuid)
+		if err != nil {
+			t.Fatalf("Expected to recover sequence but received error = %v", err.Error())
+		}
+
+		if sequence != expected {
+			t.Fatalf("Expected recovered sequence to be %v, but got %v", expected, sequence)
+		}
+	}
+
+	if _, err := Init(WithRecoverableSequence(), WithFingerprintMarker()); err == nil {
+		t.Fatalf("Expected to receive an error when combining the sequence and fingerprint marker, but got nothing")
+	}
+}
+
+func TestRandomFuncSampling(t *testing.T) {
+	calls := 0
+	sometimesOutOfRange := func() float64 {
+		calls++
+		if calls%5 == 0 {
+			return 1.5
+		}
+		return rand.Float64()
+	}
+
+	if _, err := Init(WithRandomFunc(sometimesOutOfRange)); err == nil {
+		t.Fatalf("Expected to receive an error for a random function that is sometimes out of range, but got nothing")
+	}
+
+	if _, err := Init(WithRandomFunc(func() float64 { return 0.5 })); err == nil {
+		t.Fatalf("Expected to receive an error for a constant random function, but got nothing")
+	}
+
+	calls = 0
+	if _, err := Init(WithRandomFuncSamples(sometimesOutOfRange, 1)); err != nil {
+		t.Fatalf("Expected a single sample to accept the random function but received error = %v", err.Error())
+	}
+
+	if _, err := Init(WithRandomFunc(rand.Float64)); err != nil {
+		t.Fatalf("Expected to accept rand.Float64 but received error = %v", err.Error())
+	}
+
+	if _, err := Init(WithRandomFuncSamples(rand.Float64, 0)); err == nil {
+		t.Fatalf("Expected to receive an error for zero samples, but got nothing")
+	}
+}
+
+func TestGenerationTimeout(t *testing.T) {
+	slow := false
+	generator, err := NewGenerator(
+		WithRandomFunc(func() float64 {
+			if slow {
+				time.Sleep(10 * time.Millisecond)
+			}
+			return rand.Float64()
+		}),
+		WithGenerationTimeout(5*time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if _, err := generator.GenerateE(); err != nil {
+		t.Fatalf("Expected to generate Cuid within the timeout but received error = %v", err.Error())
+	}
+
+	slow = true
+
+	if _, err := generator.GenerateE(); !errors.Is(err, ErrGenerationTimeout) {
+		t.Fatalf("Expected to receive ErrGenerationTimeout for a slow random function, but got %v", err)
+	}
+
+	if _, err := Init(WithGenerationTimeout(time.Second), WithChainedGeneration()); err == nil {
+		t.Fatalf("Expected to receive an error when combining a generation timeout with chained generation, but got nothing")
+	}
+}
+
+type failingReader struct{}
+
+func (failingReader) Read(p []byte) (int, error) {
+	return 0, errors.New("entropy source unavailable")
+}
+
+func TestGenerateWithFailingEntropyReader(t *testing.T) {
+	generator, err := NewGenerator(WithEntropyReader(failingReader{}))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuid, err := generator.GenerateE()
+	if err == nil {
+		t.Fatalf("Expected to receive an error for a failing entropy reader, but got %v", cuid)
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected Generate to panic for a failing entropy reader")
+		}
+	}()
+	generator.Generate()
+}
+
+func TestGenerateWithNoPanic(t *testing.T) {
+	generator, err := NewGenerator(WithEntropyReader(failingReader{}), WithNoPanic())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if cuid := generator.Generate(); cuid != "" {
+		t.Fatalf("Expected an empty Cuid for a failing entropy reader, but got %v", cuid)
+	}
+
+	if cuid := generator.GenerateFromContext(context.Background()); cuid != "" {
+		t.Fatalf("Expected an empty Cuid from context for a failing entropy reader, but got %v", cuid)
+	}
+
+	if _, err := generator.GenerateE(); err == nil {
+		t.Fatalf("Expected GenerateE to still return an error for a failing entropy reader")
+	}
+}
+
+type repeatingCounter struct {
+	mutex sync.Mutex
+	calls int64
+}
+
+// Repeats each count twice, to force duplicates
+func (c *repeatingCounter) Increment() int64 {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.calls++
+	return c.calls / 2
+}
+
+func TestGenerateUntil(t *testing.T) {
+	generator, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	attempts := 0
+	cuid, err := generator.GenerateUntil(func(cuid string) bool {
+		attempts++
+		return attempts == 3
+	}, 5)
+	if err != nil {
+		t.Fatalf("Expected to generate a Cuid satisfying the predicate but received error = %v", err.Error())
+	}
+
+	if !IsCuid(cuid) || attempts != 3 {
+		t.Fatalf("Expected a valid Cuid after 3 attempts, but got %v after %v attempts", cuid, attempts)
+	}
+
+	if _, err := generator.GenerateUntil(func(string) bool { return false }, 5); err == nil {
+		t.Fatalf("Expected to receive an error when attempts are exhausted, but got nothing")
+	}
+
+	if _, err := generator.GenerateUntil(nil, 5); err == nil {
+		t.Fatalf("Expected to receive an error for a nil predicate, but got nothing")
+	}
+}
+
+func TestGenerateGroup(t *testing.T) {
+	timeCalls := 0
+	generator, err := NewGenerator(
+		WithSortableDescending(),
+		WithTimeFunc(func() time.Time {
+			timeCalls++
+			return time.UnixMilli(1700000000000 + int64(timeCalls))
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	group := generator.GenerateGroup(50)
+	if len(group) != 50 {
+		t.Fatalf("Expected to generate 50 Cuids, but got %v", len(group))
+	}
+
+	if timeCalls != 1 {
+		t.Fatalf("Expected the time to be read once per group, but got %v reads", timeCalls)
+	}
+
+	set := map[string]struct{}{}
+	for _, cuid := range group {
+		if !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid, but got %v", cuid)
+		}
+
+		if cuid[:MinSortableIdLength-minSortableEntropyLength] != group[0][:MinSortableIdLength-minSortableEntropyLength] {
+			t.Fatalf("Expected Cuids in a group to share a time prefix, but got %v and %v", group[0], cuid)
+		}
+
+		if _, exists := set[cuid]; exists {
+			t.Fatalf("Expected Cuids in a group to be unique, but got %v twice", cuid)
+		}
+		set[cuid] = struct{}{}
+	}
+
+	if group := generator.GenerateGroup(0); len(group) != 0 {
+		t.Fatalf("Expected an empty group for n = 0, but got %v", group)
+	}
+}
+
+func TestGenerateNUnique(t *testing.T) {
+	generator, err := NewGenerator(
+		WithRandomFuncSamples(func() float64 { return 0.5 }, 1),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(0) }),
+		WithFingerprint("fixed"),
+		WithSessionCounter(&repeatingCounter{}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuids, err := generator.GenerateNUnique(50)
+	if err != nil {
+		t.Fatalf("Expected to generate a unique batch but received error = %v", err.Error())
+	}
+
+	set := map[string]struct{}{}
+	for _, cuid := range cuids {
+		set[cuid] = struct{}{}
+	}
+
+	if len(cuids) != 50 || len(set) != 50 {
+		t.Fatalf("Expected 50 distinct Cuids, but got %v Cuids with %v distinct", len(cuids), len(set))
+	}
+
+	if _, err := generator.GenerateNUnique(-1); err == nil {
+		t.Fatalf("Expected to receive an error for a negative batch size, but got nothing")
+	}
+}
+
+type traceIdKey struct{}
+
+func TestGenerateFromContext(t *testing.T) {
+	extractor := func(ctx context.Context) (string, bool) {
+		traceId, ok := ctx.Value(traceIdKey{}).(string)
+		return traceId, ok
+	}
+
+	traced, _ := NewReproducible(42, WithTraceIdExtractor(extractor))
+	untraced, _ := NewReproducible(42, WithTraceIdExtractor(extractor))
+	plain, _ := NewReproducible(42)
+
+	ctx := context.WithValue(context.Background(), traceIdKey{}, "4bf92f3577b34da6a3ce929d0e0e4736")
+
+	tracedCuid := traced.GenerateFromContext(ctx)
+	untracedCuid := untraced.GenerateFromContext(context.Background())
+	plainCuid := plain.Generate()
+
+	if !IsCuid(tracedCuid) {
+		t.Fatalf("Expected to generate a valid Cuid from a traced context, but got %v", tracedCuid)
+	}
+
+	if tracedCuid == plainCuid {
+		t.Fatalf("Expected the trace id to be folded into the Cuid")
+	}
+
+	if untracedCuid != plainCuid {
+		t.Fatalf("Expected a context without a trace id to behave like Generate")
+	}
+}
+
+func TestGenerateChild(t *testing.T) {
+	parentID := Generate()
+
+	childGenerator, _ := NewReproducible(42)
+	plain, _ := NewReproducible(42)
+
+	child := childGenerator.GenerateChild(parentID)
+	if !IsCuid(child) {
+		t.Fatalf("Expected to generate a valid child Cuid, but got %v", child)
+	}
+
+	if child == plain.Generate() {
+		t.Fatalf("Expected the parent id to be folded into the child Cuid")
+	}
+
+	if sibling := childGenerator.GenerateChild(parentID); sibling == child {
+		t.Fatalf("Expected children of the same parent to be distinct")
+	}
+}
+
+// Internal Tests
+func TestSessionCounter(t *testing.T) {
+	var initialSessionCount int64 = 10
+	sessionCounter := NewSessionCounter(initialSessionCount)
+	expectedCounts := []int64{11, 12, 13, 14}
+	actualCounts := []int64{
+		sessionCounter.Increment(),
+		sessionCounter.Increment(),
+		sessionCounter.Increment(),
+		sessionCounter.Increment(),
+	}
+
+	for index, actualCount := range actualCounts {
+		expectedCount := expectedCounts[index]
+		if actualCount != expectedCount {
+			t.Error("Expected session counts to increment by one for each successive call")
+			t.Errorf("For an initial session count of %v, expected %v", initialSessionCount, expectedCounts)
+			t.Fatalf("Got %v", actualCounts)
+		}
+	}
+}
+
+func TestTimestampResolution(t *testing.T) {
+	now := time.UnixMilli(1700000000123).Add(456 * time.Microsecond)
+	testCases := map[time.Duration]int64{
+		time.Millisecond: 1700000000123,
+		time.Second:      1700000000,
+		time.Microsecond: 1700000000123456,
+	}
+
+	for resolution, expected := range testCases {
+		if actual := getTimestamp(now, resolution); actual != expected {
+			t.Fatalf("Expected timestamp at a resolution of %v to be %v, but got %v", resolution, expected, actual)
+		}
+	}
+
+	timeFunc := WithTimeFunc(func() time.Time { return now })
+	millisecond, _ := NewReproducible(42, timeFunc)
+	second, _ := NewReproducible(42, timeFunc, WithTimeResolution(time.Second))
+	if millisecond.Generate() == second.Generate() {
+		t.Fatalf("Expected the time resolution to affect the generated Cuid")
+	}
+
+	if _, err := Init(WithTimeResolution(0)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithTimeResolution(0)), but got nothing")
+	}
+}
+
+func TestFormatCount(t *testing.T) {
+	testCases := []struct {
+		count    int64
+		width    int
+		expected string
+	}{
+		{1295, 0, "zz"},
+		{1295, 4, "00zz"},
+		{46655, 2, "zz"},
+		{46655, 3, "zzz"},
+	}
+
+	for _, testCase := range testCases {
+		if actual := formatCount(testCase.count, testCase.width); actual != testCase.expected {
+			t.Fatalf(
+				"Expected formatCount(%v, %v) to be %v, but got %v",
+				testCase.count, testCase.width, testCase.expected, actual,
+			)
+		}
+	}
+
+	if _, err := Init(WithCounterWidth(0)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithCounterWidth(0)), but got nothing")
+	}
+}
+
+func TestFingerprintInfluence(t *testing.T) {
+	if limited := limitFingerprint("abcdef", 3); limited != "abc" {
+		t.Fatalf("Expected fingerprint to be limited to abc, but got %v", limited)
+	}
+
+	if unlimited := limitFingerprint("abcdef", 0); unlimited != "abcdef" {
+		t.Fatalf("Expected fingerprint to be unlimited by default, but got %v", unlimited)
+	}
+
+	first, _ := NewReproducible(42, WithFingerprint("node-1"), WithFingerprintInfluence(4))
+	second, _ := NewReproducible(42, WithFingerprint("node-2"), WithFingerprintInfluence(4))
+	if first.Generate() != second.Generate() {
+		t.Fatalf("Expected fingerprint characters beyond the influence limit to not affect the Cuid")
+	}
+
+	if _, err := Init(WithFingerprintInfluence(0)); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithFingerprintInfluence(0)), but got nothing")
+	}
+}
+
+func TestClockCounter(t *testing.T) {
+	clockCounter := NewClockCounter()
+
+	results := make(chan []int64, 4)
+	for w := 0; w < 4; w++ {
+		go func() {
+			counts := []int64{}
+			for i := 0; i < 1000; i++ {
+				counts = append(counts, clockCounter.Increment())
+			}
+			results <- counts
+		}()
+	}
+
+	set := map[int64]struct{}{}
+	for w := 0; w < 4; w++ {
+		counts := <-results
+		for index, count := range counts {
+			if index > 0 && count <= counts[index-1] {
+				t.Fatalf("Expected clock counter values to be strictly increasing, but got %v after %v", count, counts[index-1])
+			}
+			if _, exists := set[count]; exists {
+				t.Fatalf("Expected clock counter values to never repeat, but %v was repeated", count)
+			}
+			set[count] = struct{}{}
+		}
+	}
+
+	if _, err := Init(WithSessionCounter(clockCounter)); err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator with a clock counter but received error = %v", err.Error())
+	}
+}
+
+func TestPaddedSessionCounter(t *testing.T) {
+	paddedCounter := NewPaddedSessionCounter(10)
+	if count := paddedCounter.Increment(); count != 11 {
+		t.Fatalf("Expected padded session counter to increment to 11, but got %v", count)
+	}
+
+	if size := unsafe.Sizeof(PaddedSessionCounter{}); size != cacheLineSize {
+		t.Fatalf("Expected padded session counter to fill a cache line of %v bytes, but got %v", cacheLineSize, size)
+	}
+}
+
+func TestCreatingFingerprintWithEnvKeyString(t *testing.T) {
+	fingerprint := createFingerprint(rand.Float64, getEnvironmentKeyString())
+	if len(fingerprint) < MinIdLength {
+		t.Error("Could not generate fingerprint of adequate length")
+		t.Fatalf("Expected length to be at least %v, but got %v", MinIdLength, len(fingerprint))
+	}
+}
+
+func TestFingerprintFromFile(t *testing.T) {
+	directory := t.TempDir()
+
+	path := filepath.Join(directory, "machine-id")
+	if err := os.WriteFile(path, []byte("b08dfa6083e7567a1921a715000001fb\n"), 0o644); err != nil {
+		t.Fatalf("Expected to write fingerprint file but received error = %v", err.Error())
+	}
+
+	first, err := NewGenerator(WithFingerprintFromFile(path))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	second, err := NewGenerator(WithFingerprintFromFile(path))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if first.config.Fingerprint != second.config.Fingerprint {
+		t.Fatalf("Expected fingerprints derived from the same file to match")
+	}
+
+	if _, err := NewGenerator(WithFingerprintFromFile(filepath.Join(directory, "missing"))); err == nil {
+		t.Fatalf("Expected to receive an error for a missing fingerprint file, but got nothing")
+	}
+
+	emptyPath := filepath.Join(directory, "empty")
+	if err := os.WriteFile(emptyPath, []byte(" \n"), 0o644); err != nil {
+		t.Fatalf("Expected to write fingerprint file but received error = %v", err.Error())
+	}
+
+	if _, err := NewGenerator(WithFingerprintFromFile(emptyPath)); err == nil {
+		t.Fatalf("Expected to receive an error for an empty fingerprint file, but got nothing")
+	}
+}
+
+func TestFingerprintProvider(t *testing.T) {
+	generator, err := NewGenerator(WithFingerprintProvider(func() (string, error) {
+		return "provided-fingerprint", nil
+	}))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if fingerprint := generator.Config().Fingerprint; fingerprint != "provided-fingerprint" {
+		t.Fatalf("Expected fingerprint to be provided-fingerprint, but got %v", fingerprint)
+	}
+
+	providerErr := errors.New("config service unavailable")
+	_, err = NewGenerator(WithFingerprintProvider(func() (string, error) {
+		return "", providerErr
+	}))
+	if !errors.Is(err, providerErr) {
+		t.Fatalf("Expected to receive the provider error, but got %v", err)
+	}
+
+	if _, err := NewGenerator(WithFingerprintProvider(nil)); err == nil {
+		t.Fatalf("Expected to receive an error for a nil fingerprint provider, but got nothing")
+	}
+}
+
+func TestShardId(t *testing.T) {
+	first, _ := NewGenerator(WithFingerprint("node"), WithShardId(1))
+	second, _ := NewGenerator(WithShardId(1), WithFingerprint("node"))
+	other, _ := NewGenerator(WithFingerprint("node"), WithShardId(2))
+
+	if first.Config().Fingerprint != second.Config().Fingerprint {
+		t.Fatalf("Expected the shard id to be folded in regardless of the order of options")
+	}
+
+	if first.Config().Fingerprint == other.Config().Fingerprint {
+		t.Fatalf("Expected different shard ids to yield different fingerprints")
+	}
+
+	if _, err := Init(WithShardId(1), WithShardId(2)); err == nil {
+		t.Fatalf("Expected to receive an error for conflicting shard ids, but got nothing")
+	}
+}
+
+func TestSharedFingerprint(t *testing.T) {
+	first, err := NewGenerator(WithSharedFingerprint())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	second, _ := NewGenerator(WithSharedFingerprint())
+	unshared, _ := NewGenerator()
+
+	if first.Config().Fingerprint != SharedFingerprint() || second.Config().Fingerprint != SharedFingerprint() {
+		t.Fatalf("Expected generators to use the shared fingerprint")
+	}
+
+	if unshared.Config().Fingerprint == SharedFingerprint() {
+		t.Fatalf("Expected generators without the option to derive their own fingerprint")
+	}
+
+	if first.Generate() == second.Generate() {
+		t.Fatalf("Expected generators sharing a fingerprint to yield different Cuids")
+	}
+}
+
+func TestBuildVersion(t *testing.T) {
+	plain, _ := NewGenerator(WithFingerprint("node"))
+	first, err := NewGenerator(WithFingerprint("node"), WithBuildVersion("v1.2.3"))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	second, _ := NewGenerator(WithBuildVersion("v1.2.3"), WithFingerprint("node"))
+	other, _ := NewGenerator(WithFingerprint("node"), WithBuildVersion("v1.2.4"))
+
+	if first.Config().Fingerprint == plain.Config().Fingerprint {
+		t.Fatalf("Expected the build version to change the fingerprint")
+	}
+
+	if first.Config().Fingerprint != second.Config().Fingerprint {
+		t.Fatalf("Expected the build version to be folded in regardless of the order of options")
+	}
+
+	if first.Config().Fingerprint == other.Config().Fingerprint {
+		t.Fatalf("Expected different build versions to yield different fingerprints")
+	}
+
+	if _, err := Init(WithBuildVersion(" ")); err == nil {
+		t.Fatalf("Expected to receive an error for an empty build version, but got nothing")
+	}
+}
+
+func TestProcessMemoryEntropy(t *testing.T) {
+	plain, _ := NewGenerator(WithFingerprint("node"))
+	first, err := NewGenerator(WithFingerprint("node"), WithProcessMemoryEntropy())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	second, _ := NewGenerator(WithProcessMemoryEntropy(), WithFingerprint("node"))
+
+	if first.Config().Fingerprint == plain.Config().Fingerprint {
+		t.Fatalf("Expected process entropy to change the fingerprint")
+	}
+
+	if first.Config().Fingerprint != second.Config().Fingerprint {
+		t.Fatalf("Expected process entropy to be captured once per process")
+	}
+
+	if cuid := first.Generate(); !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with process entropy, but got %v", cuid)
+	}
+}
+
+func TestFingerprintLogger(t *testing.T) {
+	loggedFingerprints := []string{}
+
+	generator, err := NewGenerator(
+		WithFingerprint("node-1"),
+		WithFingerprintLogger(func(fingerprint string) {
+			loggedFingerprints = append(loggedFingerprints, fingerprint)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	generator.Generate()
+
+	if len(loggedFingerprints) != 1 || loggedFingerprints[0] != "node-1" {
+		t.Fatalf("Expected the fingerprint to be logged once, but got %v", loggedFingerprints)
+	}
+
+	if _, err := Init(WithFingerprintLogger(nil)); err != nil {
+		t.Fatalf("Expected a nil fingerprint logger to be ignored, but received error = %v", err.Error())
+	}
+}
+
+func TestGenerateWithLength(t *testing.T) {
+	generator, _ := NewGenerator(WithLength(24))
+
+	cuid, err := generator.GenerateWithLength(10)
+	if err != nil {
+		t.Fatalf("Expected to generate a Cuid with a length of 10 but received error = %v", err.Error())
+	}
+
+	if len(cuid) != 10 || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with a length of 10, but got %v", cuid)
+	}
+
+	if cuid := generator.Generate(); len(cuid) != 24 {
+		t.Fatalf("Expected the generator's length to be unchanged, but got a Cuid with a length of %v", len(cuid))
+	}
+
+	if generator.Config().Length != 24 {
+		t.Fatalf("Expected the configured length to be unchanged, but got %v", generator.Config().Length)
+	}
+
+	for _, length := range []int{MinIdLength - 1, MaxIdLength + 1} {
+		if _, err := generator.GenerateWithLength(length); err == nil {
+			t.Fatalf("Expected to receive an error for GenerateWithLength(%v), but got nothing", length)
+		}
+	}
+
+	checksummed, _ := NewGenerator(WithChecksumLength(2))
+	if _, err := checksummed.GenerateWithLength(MinIdLength); err == nil {
+		t.Fatalf("Expected to receive an error for a length below the minimum for the configured options, but got nothing")
+	}
+}
+
+func TestHashInputObserver(t *testing.T) {
+	inputs := []string{}
+
+	generator, err := NewReproducible(
+		42,
+		WithFingerprint("node-1"),
+		WithHashInputObserver(func(input string) {
+			inputs = append(inputs, input)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuid := generator.Generate()
+
+	if len(inputs) != 1 || !strings.HasSuffix(inputs[0], "node-1") {
+		t.Fatalf("Expected the hash input to be observed once, but got %v", inputs)
+	}
+
+	if cuid[1:] != Hash(inputs[0])[1:DefaultIdLength] {
+		t.Fatalf("Expected Cuid (%v) to be derived from the observed hash input (%v)", cuid, inputs[0])
+	}
+
+	if _, err := Init(WithHashInputObserver(nil)); err != nil {
+		t.Fatalf("Expected a nil hash input observer to be ignored, but received error = %v", err.Error())
+	}
+}
+
+func TestNormalizedFingerprint(t *testing.T) {
+	first, _ := NewReproducible(42, WithFingerprint("Node-1 "), WithNormalizedFingerprint())
+	second, _ := NewReproducible(42, WithNormalizedFingerprint(), WithFingerprint("node-1"))
+
+	if first.Config().Fingerprint != "node-1" || second.Config().Fingerprint != "node-1" {
+		t.Fatalf(
+			"Expected fingerprints to be normalized to node-1, but got %v and %v",
+			first.Config().Fingerprint, second.Config().Fingerprint,
+		)
+	}
+
+	if first.Generate() != second.Generate() {
+		t.Fatalf("Expected differently-cased fingerprints to yield identical generators")
+	}
+
+	firstTenants, err := NewTenantGenerator(0, WithFingerprint("Node-1 "), WithNormalizedFingerprint())
+	if err != nil {
+		t.Fatalf("Expected to initialize tenant generator but received error = %v", err.Error())
+	}
+	secondTenants, _ := NewTenantGenerator(0, WithFingerprint("node-1"), WithNormalizedFingerprint())
+
+	firstTenant, _ := firstTenants.generatorFor("tenant-a")
+	secondTenant, _ := secondTenants.generatorFor("tenant-a")
+
+	if firstTenant.Config().Fingerprint != secondTenant.Config().Fingerprint {
+		t.Fatalf(
+			"Expected differently-cased fingerprints to converge for the same tenant, but got %v and %v",
+			firstTenant.Config().Fingerprint, secondTenant.Config().Fingerprint,
+		)
+	}
+}
+
+func TestEnvironmentKeyStringWithStableKeys(t *testing.T) {
+	t.Setenv("CUID2_STABLE_KEY_B", "1")
+	t.Setenv("CUID2_STABLE_KEY_A", "2")
+	InvalidateEnvironmentCache()
+
+	envKeyString := getEnvironmentKeyString("CUID2_STABLE_KEY_B", "CUID2_STABLE_KEY_A", "CUID2_MISSING_KEY")
+	expected := "CUID2_STABLE_KEY_ACUID2_STABLE_KEY_B"
+	if envKeyString != expected {
+		t.Fatalf("Expected environment key string to be %v, but got %v", expected, envKeyString)
+	}
+
+	if _, err := Init(WithStableFingerprintKeys()); err == nil {
+		t.Fatalf("Expected to receive an error for Init(WithStableFingerprintKeys()), but got nothing")
+	}
+}
+
+func TestStableFingerprintKeys(t *testing.T) {
+	constant := func() float64 { return 0.5 }
+
+	first, err := NewGenerator(WithStableFingerprintKeys("HOME"), WithRandomFuncSamples(constant, 1))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+	second, _ := NewGenerator(WithRandomFuncSamples(constant, 1), WithStableFingerprintKeys("HOME"))
+
+	if first.Config().Fingerprint != second.Config().Fingerprint {
+		t.Fatalf("Expected the fingerprint derived from stable keys to be independent of the order of options")
+	}
+
+	first, _ = NewReproducible(42, WithStableFingerprintKeys("HOME"))
+	second, _ = NewReproducible(42, WithStableFingerprintKeys("HOME"))
+	if first.Generate() != second.Generate() {
+		t.Fatalf("Expected reproducible generators with stable keys to yield identical Cuids")
+	}
+
+	if _, err := Init(WithFingerprint("node-1"), WithStableFingerprintKeys("HOME")); err == nil {
+		t.Fatalf("Expected to receive an error when combining stable keys with an explicit fingerprint, but got nothing")
+	}
+
+	if _, err := Init(WithStableFingerprintKeys("HOME"), WithFingerprint("node-1")); err == nil {
+		t.Fatalf("Expected to receive an error when combining an explicit fingerprint with stable keys, but got nothing")
+	}
+}
+
+func TestEnvironmentCache(t *testing.T) {
+	before := getEnvironmentKeyString("CUID2_CACHED_KEY")
+	t.Setenv("CUID2_CACHED_KEY", "1")
+
+	if cached := getEnvironmentKeyString("CUID2_CACHED_KEY"); cached != before {
+		t.Fatalf("Expected environment key string to be cached as %q, but got %q", before, cached)
+	}
+
+	InvalidateEnvironmentCache()
+	if refreshed := getEnvironmentKeyString("CUID2_CACHED_KEY"); refreshed != "CUID2_CACHED_KEY" {
+		t.Fatalf("Expected environment key string to be refreshed after invalidation, but got %q", refreshed)
 	}
 }
 
@@ -0,0 +1,56 @@
+// Package cuid2test provides deterministic stand-ins for Cuid generators, for
+// use in the tests of packages that generate Cuids.
+package cuid2test
+
+import (
+	"fmt"
+	"sync"
+)
+
+// The method set shared by *cuid2.Generator and the generators in this
+// package, which code under test can depend on to allow injecting either
+type Generator interface {
+	Generate() string
+}
+
+// A generator that always yields the same id
+type Fixed struct {
+	id string
+}
+
+// Creates a generator that always yields the given id
+func FixedGenerator(id string) *Fixed {
+	return &Fixed{id: id}
+}
+
+func (f *Fixed) Generate() string {
+	return f.id
+}
+
+// A generator that yields a predefined sequence of ids
+type Sequence struct {
+	mutex sync.Mutex
+	ids   []string
+	next  int
+}
+
+// Creates a generator that yields the given ids in order, panicking once they
+// are exhausted, so that tests fail loudly when more ids are generated than
+// expected
+func SequenceGenerator(ids ...string) *Sequence {
+	return &Sequence{ids: append([]string(nil), ids...)}
+}
+
+func (s *Sequence) Generate() string {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+
+	if s.next == len(s.ids) {
+		panic(fmt.Sprintf("Error: the sequence generator is exhausted after %v ids", len(s.ids)))
+	}
+
+	id := s.ids[s.next]
+	s.next++
+
+	return id
+}
@@ -0,0 +1,40 @@
+package cuid2test
+
+import (
+	"testing"
+
+	"github.com/nrednav/cuid2"
+)
+
+var (
+	_ Generator = (*cuid2.Generator)(nil)
+	_ Generator = (*Fixed)(nil)
+	_ Generator = (*Sequence)(nil)
+)
+
+func TestFixedGenerator(t *testing.T) {
+	generator := FixedGenerator("yi7rqj1trke")
+
+	for i := 0; i < 3; i++ {
+		if id := generator.Generate(); id != "yi7rqj1trke" {
+			t.Fatalf("Expected fixed generator to yield yi7rqj1trke, but got %v", id)
+		}
+	}
+}
+
+func TestSequenceGenerator(t *testing.T) {
+	generator := SequenceGenerator("a1", "b2")
+
+	for _, expected := range []string{"a1", "b2"} {
+		if id := generator.Generate(); id != expected {
+			t.Fatalf("Expected sequence generator to yield %v, but got %v", expected, id)
+		}
+	}
+
+	defer func() {
+		if recover() == nil {
+			t.Fatalf("Expected an exhausted sequence generator to panic")
+		}
+	}()
+	generator.Generate()
+}
@@ -0,0 +1,101 @@
+package cuid2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Leading letter that marks a Cuid as carrying a date bucket prefix
+	dateBucketMarker = "b"
+
+	// Number of base36 characters used to encode the date bucket
+	dateBucketWidth = 6
+
+	// Minimum number of random characters that follow the date bucket
+	minDateBucketEntropyLength = 4
+
+	MinDateBucketIdLength int = len(dateBucketMarker) + dateBucketWidth + minDateBucketEntropyLength
+)
+
+var (
+	maxDateBucket = int64(math.Pow(36, dateBucketWidth)) - 1
+
+	// Latest time a date bucket may start at, beyond which a decoded bucket is
+	// considered invalid
+	maxDateBucketTime = time.Date(9999, 12, 31, 23, 59, 59, 999999999, time.UTC)
+)
+
+// Prepends a coarse time bucket, e.g. days since the Unix epoch for a unit of
+// 24 hours, to each Cuid, so that Cuids can be sharded by prefix for
+// partitioned storage and lifecycle policies.
+//
+// The Cuid starts with the marker letter "b", followed by a fixed-width, 6
+// character base36 encoding of the bucket. The remaining characters are random.
+// Requires a length of at least MinDateBucketIdLength, and a unit coarse
+// enough that the current bucket fits in 6 base36 characters (about a second
+// or more). The current bucket is checked against the configured time
+// function and offset when the generator is created, and again on every
+// generation, which fails with an error once the clock leaves the encodable
+// range, e.g. before the Unix epoch.
+func WithDateBucketPrefix(unit time.Duration) Option {
+	return func(config *Config) error {
+		if unit < time.Millisecond {
+			return fmt.Errorf("Error: the date bucket unit must be at least 1 millisecond")
+		}
+		config.DateBucketUnit = unit
+		return nil
+	}
+}
+
+// Returns the start time of the date bucket of a Cuid generated with
+// WithDateBucketPrefix, given the same bucket unit.
+//
+// The prefix cannot be told apart from a random first letter, so any Cuid
+// that starts with "b" is decoded. Buckets that start after the year 9999 are
+// rejected, but this only catches some Cuids generated without the prefix.
+func DateBucketOf(cuid string, unit time.Duration) (time.Time, error) {
+	if unit < time.Millisecond {
+		return time.Time{}, fmt.Errorf("Error: the date bucket unit must be at least 1 millisecond")
+	}
+
+	if !IsCuid(cuid) || len(cuid) < MinDateBucketIdLength || !strings.HasPrefix(cuid, dateBucketMarker) {
+		return time.Time{}, fmt.Errorf("Error: Cuid (%v) does not have a date bucket prefix", cuid)
+	}
+
+	encodedBucket := cuid[len(dateBucketMarker) : len(dateBucketMarker)+dateBucketWidth]
+	bucket, err := strconv.ParseInt(encodedBucket, 36, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Error: could not decode date bucket of Cuid (%v): %w", cuid, err)
+	}
+
+	if bucket > maxDateBucketTime.UnixMilli()/unit.Milliseconds() {
+		return time.Time{}, fmt.Errorf("Error: Cuid (%v) has a date bucket outside the supported range", cuid)
+	}
+
+	return time.UnixMilli(bucket * unit.Milliseconds()), nil
+}
+
+// Checks that the bucket of the configured current time can be encoded
+func validateDateBucket(config *Config) error {
+	_, err := createDateBucketPrefix(config.TimeFunc().Add(config.TimeOffset), config.DateBucketUnit)
+	return err
+}
+
+func getDateBucket(now time.Time, unit time.Duration) int64 {
+	return now.UnixMilli() / unit.Milliseconds()
+}
+
+func createDateBucketPrefix(now time.Time, unit time.Duration) (string, error) {
+	bucket := getDateBucket(now, unit)
+	if now.UnixMilli() < 0 || bucket > maxDateBucket || now.After(maxDateBucketTime) {
+		return "", fmt.Errorf("Error: the date bucket unit (%v) cannot encode the time (%v)", unit, now)
+	}
+
+	encodedBucket := strconv.FormatInt(bucket, 36)
+	padding := strings.Repeat("0", dateBucketWidth-len(encodedBucket))
+	return dateBucketMarker + padding + encodedBucket, nil
+}
@@ -0,0 +1,105 @@
+package cuid2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDateBucketPrefix(t *testing.T) {
+	day := 24 * time.Hour
+	bucketStart := time.Date(2024, 10, 26, 0, 0, 0, 0, time.UTC)
+	now := bucketStart.Add(-time.Millisecond)
+
+	generator, err := NewGenerator(
+		WithDateBucketPrefix(day),
+		WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	beforeBoundary := generator.Generate()
+	now = bucketStart
+	atBoundary := generator.Generate()
+	now = bucketStart.Add(day - time.Millisecond)
+	endOfBucket := generator.Generate()
+
+	for _, cuid := range []string{beforeBoundary, atBoundary, endOfBucket} {
+		if len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with a date bucket prefix, but got %v", cuid)
+		}
+	}
+
+	prefixLength := MinDateBucketIdLength - minDateBucketEntropyLength
+
+	if beforeBoundary[:prefixLength] == atBoundary[:prefixLength] {
+		t.Fatalf("Expected Cuids on either side of a bucket boundary to have different prefixes")
+	}
+
+	if atBoundary[:prefixLength] != endOfBucket[:prefixLength] {
+		t.Fatalf("Expected Cuids within the same bucket to share a prefix")
+	}
+
+	bucket, err := DateBucketOf(endOfBucket, day)
+	if err != nil {
+		t.Fatalf("Expected to get date bucket but received error = %v", err.Error())
+	}
+
+	if !bucket.Equal(bucketStart) {
+		t.Fatalf("Expected date bucket to start at %v, but got %v", bucketStart, bucket)
+	}
+
+	if _, err := Init(WithDateBucketPrefix(day), WithLength(MinDateBucketIdLength-1)); err == nil {
+		t.Fatalf("Expected to receive an error for a Cuid too short for the date bucket, but got nothing")
+	}
+
+	if _, err := Init(WithDateBucketPrefix(time.Millisecond)); err == nil {
+		t.Fatalf("Expected to receive an error for a date bucket unit that is too fine, but got nothing")
+	}
+
+	// The epoch is within range of a millisecond unit, but the offset moves it
+	// out of range
+	epoch := func() time.Time { return time.UnixMilli(0) }
+	if _, err := Init(WithDateBucketPrefix(time.Millisecond), WithTimeFunc(epoch)); err != nil {
+		t.Fatalf("Expected the date bucket to be validated against the configured clock, but received error = %v", err.Error())
+	}
+
+	if _, err := Init(WithTimeFunc(epoch), WithTimeOffset(time.Duration(maxDateBucket+1)*time.Millisecond), WithDateBucketPrefix(time.Millisecond)); err == nil {
+		t.Fatalf("Expected to receive an error for a date bucket out of range of the offset clock, but got nothing")
+	}
+
+	if _, err := Init(WithDateBucketPrefix(day), WithTimeFunc(func() time.Time { return time.UnixMilli(-int64(day / time.Millisecond)) })); err == nil {
+		t.Fatalf("Expected to receive an error for a clock before the Unix epoch, but got nothing")
+	}
+
+	if _, err := DateBucketOf("bzzzzzzabcdefghijklmnopq", day); err == nil {
+		t.Fatalf("Expected to receive an error for a date bucket after the year 9999, but got nothing")
+	}
+}
+
+func TestDateBucketPrefixOutOfRange(t *testing.T) {
+	now := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	clock := func() time.Time { return now }
+
+	generator, err := NewGenerator(WithDateBucketPrefix(time.Second), WithTimeFunc(clock))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	lenient, _ := NewGenerator(WithDateBucketPrefix(time.Second), WithTimeFunc(clock), WithNoPanic())
+
+	for _, outOfRange := range []time.Time{
+		time.Date(2039, 1, 1, 0, 0, 0, 0, time.UTC),
+		time.UnixMilli(-1),
+	} {
+		now = outOfRange
+
+		if cuid, err := generator.GenerateE(); err == nil {
+			t.Fatalf("Expected to receive an error for a date bucket out of range at %v, but got %v", now, cuid)
+		}
+
+		if cuid := lenient.Generate(); cuid != "" {
+			t.Fatalf("Expected an empty Cuid for a date bucket out of range at %v, but got %v", now, cuid)
+		}
+	}
+}
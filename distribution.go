@@ -0,0 +1,37 @@
+package cuid2
+
+// Tallies the first letter of each generated Cuid, so that Generator.Distribution
+// can expose it, e.g. on a dashboard to detect bias from a misconfigured random
+// function in production.
+//
+// The first letters of Cuids from a healthy random function are uniformly
+// distributed across the letters of the encoding, or fixed to "a" with
+// WithoutFirstLetterRandomness. Sortable, date bucket and shard hint options
+// derive the first letter from the time or the hash instead, so they cannot be
+// combined with this option. Tracking costs one atomic increment per Cuid.
+func WithDistributionTracking() Option {
+	return func(config *Config) error {
+		config.DistributionTracking = true
+		return nil
+	}
+}
+
+// Returns the number of generated Cuids starting with each letter of the
+// alphabet, indexed from 'a' to 'z', when WithDistributionTracking is set.
+//
+// The counts are read one at a time, so a snapshot taken during generation may
+// be off by the Cuids generated while it is taken.
+func (g *Generator) Distribution() [26]int {
+	var distribution [26]int
+	for index := range distribution {
+		distribution[index] = int(g.distribution[index].Load())
+	}
+
+	return distribution
+}
+
+func (g *Generator) trackDistribution(cuid string) {
+	if letter := cuid[0]; letter >= 'a' && letter <= 'z' {
+		g.distribution[letter-'a'].Add(1)
+	}
+}
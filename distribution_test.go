@@ -0,0 +1,49 @@
+package cuid2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestDistributionTracking(t *testing.T) {
+	generator, err := NewGenerator(WithDistributionTracking())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	n := 26000
+	expected := [26]int{}
+	for i := 0; i < n; i++ {
+		expected[generator.Generate()[0]-'a']++
+	}
+
+	distribution := generator.Distribution()
+	if distribution != expected {
+		t.Fatalf("Expected the distribution to be %v, but got %v", expected, distribution)
+	}
+
+	// Roughly 1000 Cuids per letter are expected from a healthy random function
+	for index, count := range distribution {
+		if count < 700 || count > 1300 {
+			t.Fatalf("Expected the count for %c to be close to 1000, but got %v", 'a'+index, count)
+		}
+	}
+
+	biased, _ := NewGenerator(WithDistributionTracking(), WithoutFirstLetterRandomness())
+	biased.Generate()
+	if count := biased.Distribution()[0]; count != 1 {
+		t.Fatalf("Expected the fixed first letter to be tallied, but got %v", biased.Distribution())
+	}
+
+	for _, option := range []Option{WithSortableDescending(), WithDateBucketPrefix(24 * time.Hour), WithShardHint(4)} {
+		if _, err := Init(WithDistributionTracking(), option); err == nil {
+			t.Fatalf("Expected to receive an error when combining distribution tracking with an option that derives the first letter, but got nothing")
+		}
+	}
+
+	untracked, _ := NewGenerator()
+	untracked.Generate()
+	if untracked.Distribution() != [26]int{} {
+		t.Fatalf("Expected no distribution to be tracked by default, but got %v", untracked.Distribution())
+	}
+}
@@ -0,0 +1,42 @@
+package cuid2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Position of the character that encodes the length of a Cuid generated with
+// WithEmbeddedLength
+const embeddedLengthPosition = 1
+
+// Embeds the length of each Cuid into its second character, in base36, so that
+// parsers can recover the intended length of a Cuid with LengthOf, e.g. when
+// Cuids are stored in fixed-width fields.
+//
+// This costs one character of entropy and raises the minimum length by one.
+// The length must not exceed MaxIdLength. Cannot be combined with non-base36
+// encodings, sortable or date bucket options, which use the second character.
+func WithEmbeddedLength() Option {
+	return func(config *Config) error {
+		config.EmbeddedLength = true
+		return nil
+	}
+}
+
+// Returns the length embedded in a Cuid generated with WithEmbeddedLength
+func LengthOf(cuid string) (int, error) {
+	if !IsCuid(cuid) || len(cuid) <= embeddedLengthPosition {
+		return 0, fmt.Errorf("Error: Cuid (%v) is not valid", cuid)
+	}
+
+	length := strings.IndexByte(Base36Digits, cuid[embeddedLengthPosition])
+	if length < MinIdLength+embeddedLengthPosition || length > MaxIdLength {
+		return 0, fmt.Errorf("Error: Cuid (%v) does not have a valid embedded length", cuid)
+	}
+
+	return length, nil
+}
+
+func embedLength(cuid string, length int) string {
+	return cuid[:embeddedLengthPosition] + string(Base36Digits[length]) + cuid[embeddedLengthPosition+1:]
+}
@@ -0,0 +1,40 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestEmbeddedLength(t *testing.T) {
+	for _, length := range []int{MinIdLength + 1, DefaultIdLength, MaxIdLength} {
+		generator, err := NewGenerator(WithLength(length), WithEmbeddedLength())
+		if err != nil {
+			t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+		}
+
+		cuid := generator.Generate()
+		if len(cuid) != length || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with a length of %v, but got %v", length, cuid)
+		}
+
+		embeddedLength, err := LengthOf(cuid)
+		if err != nil {
+			t.Fatalf("Expected to read embedded length but received error = %v", err.Error())
+		}
+
+		if embeddedLength != length {
+			t.Fatalf("Expected embedded length of %v to be %v, but got %v", cuid, length, embeddedLength)
+		}
+	}
+
+	if _, err := LengthOf("azaaa"); err == nil {
+		t.Fatalf("Expected to receive an error for an out of bounds embedded length, but got nothing")
+	}
+
+	if _, err := Init(WithLength(MinIdLength), WithEmbeddedLength()); err == nil {
+		t.Fatalf("Expected to receive an error for a length too short to embed, but got nothing")
+	}
+
+	if _, err := Init(WithLengthUnsafe(MaxIdLength+1), WithEmbeddedLength()); err == nil {
+		t.Fatalf("Expected to receive an error for a length too long to embed, but got nothing")
+	}
+}
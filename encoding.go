@@ -0,0 +1,109 @@
+package cuid2
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"regexp"
+	"strings"
+
+	"golang.org/x/crypto/sha3"
+)
+
+// The encoding of the characters of a generated Cuid
+type Encoding int
+
+const (
+	// The standard Cuid encoding, lowercase letters and digits
+	Base36 Encoding = iota
+
+	// A lowercase Crockford base32 encoding, which excludes the visually
+	// ambiguous letters i, l, o and u
+	CrockfordBase32
+)
+
+const (
+	crockfordBase32Alphabet = "0123456789abcdefghjkmnpqrstvwxyz"
+	crockfordBase32Letters  = "abcdefghjkmnpqrstvwxyz"
+)
+
+var crockfordBase32CuidRegex = regexp.MustCompile("^[" + crockfordBase32Letters + "][" + crockfordBase32Alphabet + "]*$")
+
+// Configures the encoding of generated Cuids.
+//
+// CrockfordBase32 is slightly less dense than Base36, but is a widely
+// understood, human-friendly encoding for external-facing ids. Such Cuids are
+// not standard base36 Cuids: although every CrockfordBase32 Cuid also passes
+// IsCuid, it should be validated with IsCuidWithEncoding, and other Cuid
+// implementations will not produce them. CrockfordBase32 cannot be combined
+// with sortable, fingerprint marker, recoverable sequence or checksum options.
+func WithEncoding(encoding Encoding) Option {
+	return func(config *Config) error {
+		if encoding != Base36 && encoding != CrockfordBase32 {
+			return fmt.Errorf("Error: unsupported encoding (%v)", encoding)
+		}
+		config.Encoding = encoding
+		return nil
+	}
+}
+
+// Restricts generated Cuids to an alphabet that excludes easily confused
+// characters (i, l, o and u), which is useful for ids that may be read aloud or
+// transcribed. Equivalent to WithEncoding(CrockfordBase32).
+func WithUnambiguousAlphabet() Option {
+	return WithEncoding(CrockfordBase32)
+}
+
+// Checks whether a given Cuid has a valid form and length for the given
+// encoding.
+//
+// Since Crockford base32 is case-insensitive, CrockfordBase32 Cuids are
+// accepted in either case.
+func IsCuidWithEncoding(cuid string, encoding Encoding) bool {
+	switch encoding {
+	case Base36:
+		return IsCuid(cuid)
+	case CrockfordBase32:
+		length := len(cuid)
+		hasValidForm := crockfordBase32CuidRegex.MatchString(strings.ToLower(cuid))
+		return hasValidForm && length >= MinIdLength && length <= MaxIdLength
+	default:
+		return false
+	}
+}
+
+// Checks whether a given Cuid has a valid form and length for the unambiguous
+// alphabet. Equivalent to IsCuidWithEncoding(cuid, CrockfordBase32).
+func IsUnambiguousCuid(cuid string) bool {
+	return IsCuidWithEncoding(cuid, CrockfordBase32)
+}
+
+func hashCrockfordBase32(input string) string {
+	hash := sha3.New512()
+	hash.Write([]byte(input))
+	hashDigest := hash.Sum(nil)
+	return encodeWithAlphabet(new(big.Int).SetBytes(hashDigest), crockfordBase32Alphabet)[1:]
+}
+
+func encodeWithAlphabet(value *big.Int, alphabet string) string {
+	base := big.NewInt(int64(len(alphabet)))
+	remainder := new(big.Int)
+	quotient := new(big.Int).Set(value)
+
+	encoded := []byte{}
+	for quotient.Sign() > 0 {
+		quotient.DivMod(quotient, base, remainder)
+		encoded = append(encoded, alphabet[remainder.Int64()])
+	}
+
+	for left, right := 0, len(encoded)-1; left < right; left, right = left+1, right-1 {
+		encoded[left], encoded[right] = encoded[right], encoded[left]
+	}
+
+	return string(encoded)
+}
+
+func getRandomCharacter(alphabet string, randomFunc func() float64) string {
+	randomIndex := int64(math.Floor(randomFunc() * float64(len(alphabet))))
+	return string(alphabet[randomIndex])
+}
@@ -0,0 +1,68 @@
+package cuid2
+
+import (
+	"math/big"
+	"strings"
+	"testing"
+)
+
+func TestUnambiguousAlphabet(t *testing.T) {
+	generator, err := NewGenerator(WithUnambiguousAlphabet())
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	for i := 0; i < 1000; i++ {
+		cuid := generator.Generate()
+		if len(cuid) != DefaultIdLength || !IsUnambiguousCuid(cuid) {
+			t.Fatalf("Expected to generate a valid unambiguous Cuid, but got %v", cuid)
+		}
+
+		if strings.ContainsAny(cuid, "ilou") {
+			t.Fatalf("Expected Cuid to not contain ambiguous characters, but got %v", cuid)
+		}
+	}
+
+	if IsUnambiguousCuid("yi7rqj1trke") {
+		t.Fatalf("Expected IsUnambiguousCuid to reject Cuids containing ambiguous characters")
+	}
+
+	if _, err := Init(WithUnambiguousAlphabet(), WithSortableDescending()); err == nil {
+		t.Fatalf("Expected to receive an error when combining the unambiguous alphabet with sortable mode, but got nothing")
+	}
+}
+
+func TestCrockfordBase32Encoding(t *testing.T) {
+	generator, err := NewGenerator(WithEncoding(CrockfordBase32))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuid := generator.Generate()
+	if !IsCuidWithEncoding(cuid, CrockfordBase32) {
+		t.Fatalf("Expected to generate a valid Crockford base32 Cuid, but got %v", cuid)
+	}
+
+	if !IsCuidWithEncoding(strings.ToUpper(cuid), CrockfordBase32) {
+		t.Fatalf("Expected Crockford base32 validation to be case-insensitive")
+	}
+
+	if IsCuidWithEncoding(strings.ToUpper(cuid), Base36) {
+		t.Fatalf("Expected base36 validation to reject uppercase Cuids")
+	}
+
+	if _, err := Init(WithEncoding(Encoding(42))); err == nil {
+		t.Fatalf("Expected to receive an error for an unsupported encoding, but got nothing")
+	}
+
+	if _, err := Init(WithEncoding(CrockfordBase32), WithChecksumLength(1)); err == nil {
+		t.Fatalf("Expected to receive an error when combining Crockford base32 with checksums, but got nothing")
+	}
+}
+
+func TestEncodeWithAlphabet(t *testing.T) {
+	value := big.NewInt(123456789)
+	if encoded := encodeWithAlphabet(value, Base36Digits); encoded != value.Text(36) {
+		t.Fatalf("Expected base36 encoding to be %v, but got %v", value.Text(36), encoded)
+	}
+}
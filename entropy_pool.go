@@ -0,0 +1,66 @@
+package cuid2
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+)
+
+const (
+	// Largest multiple of 36 that fits in a byte, random bytes at or above this
+	// value are discarded to avoid modulo bias
+	maxUnbiasedByte = 252
+)
+
+// A buffer of random bytes that serves base36 digits, refilling from its reader
+// when exhausted
+type entropyPool struct {
+	mutex    sync.Mutex
+	reader   io.Reader
+	buffer   []byte
+	position int
+}
+
+func newEntropyPool(size int, reader io.Reader) *entropyPool {
+	return &entropyPool{
+		reader:   reader,
+		buffer:   make([]byte, size),
+		position: size,
+	}
+}
+
+func (pool *entropyPool) createEntropy(length int) (string, error) {
+	pool.mutex.Lock()
+	defer pool.mutex.Unlock()
+
+	entropy := strings.Builder{}
+	entropy.Grow(length)
+
+	for entropy.Len() < length {
+		if pool.position == len(pool.buffer) {
+			if err := pool.refill(); err != nil {
+				return "", err
+			}
+		}
+
+		randomByte := pool.buffer[pool.position]
+		pool.position++
+
+		if randomByte >= maxUnbiasedByte {
+			continue
+		}
+
+		entropy.WriteByte(Base36Digits[randomByte%36])
+	}
+
+	return entropy.String(), nil
+}
+
+func (pool *entropyPool) refill() error {
+	if _, err := io.ReadFull(pool.reader, pool.buffer); err != nil {
+		return fmt.Errorf("Error: could not read random bytes for the entropy pool: %w", err)
+	}
+	pool.position = 0
+	return nil
+}
@@ -0,0 +1,51 @@
+package cuid2
+
+import (
+	"strings"
+)
+
+// The first letter of every Cuid generated with WithoutFirstLetterRandomness
+const fixedFirstLetter = "a"
+
+// Fixes the first letter of every Cuid to "a", removing the independent
+// random draw, so that the distribution of the hash body can be studied in
+// isolation, e.g. with the histograms of the collision tests.
+//
+// For analysis only, never use this in production: it removes one character
+// of entropy from every Cuid.
+func WithoutFirstLetterRandomness() Option {
+	return func(config *Config) error {
+		config.FixedFirstLetter = true
+		return nil
+	}
+}
+
+// Derives the first letter of each Cuid from the hash of its input, rather
+// than drawing it at random, so that the entire Cuid is a pure function of the
+// hash input and one random draw is saved per Cuid.
+//
+// The letter is chosen by rejection sampling: it is the first of the trailing
+// characters of the hash, which are not part of the Cuid body for lengths up to
+// MaxIdLength, that is a letter, so every letter is equally likely. Cannot be
+// combined with non-base36 encodings.
+func WithDeterministicFirstLetter() Option {
+	return func(config *Config) error {
+		config.DeterministicFirstLetter = true
+		return nil
+	}
+}
+
+// Picks the last letter of a base36 digest, scanning from its end, so that the
+// letter is uniformly distributed. The leading character of a digest is never
+// used, as it is not uniformly distributed.
+func createDeterministicFirstLetter(digest string) string {
+	for index := len(digest) - 1; index > 0; index-- {
+		if char := digest[index]; char >= 'a' && char <= 'z' {
+			return string(char)
+		}
+	}
+
+	// Only reached if every character is a digit, which is practically
+	// impossible for a full digest
+	return string(Alphabet[strings.IndexByte(Base36Digits, digest[len(digest)-1])%len(Alphabet)])
+}
@@ -0,0 +1,89 @@
+package cuid2
+
+import (
+	"strconv"
+	"testing"
+)
+
+func TestDeterministicFirstLetter(t *testing.T) {
+	first, err := NewReproducible(42, WithDeterministicFirstLetter())
+	if err != nil {
+		t.Fatalf("Expected to initialize reproducible generator but received error = %v", err.Error())
+	}
+	second, _ := NewReproducible(42, WithDeterministicFirstLetter())
+
+	for i := 0; i < 100; i++ {
+		expected := first.Generate()
+		if actual := second.Generate(); actual != expected || !IsCuid(actual) {
+			t.Fatalf("Expected call %v of both generators to yield %v, but got %v", i, expected, actual)
+		}
+	}
+
+	draws := 0
+	generator, _ := NewGenerator(
+		WithDeterministicFirstLetter(),
+		WithRandomFuncSamples(func() float64 {
+			draws++
+			return 0.5
+		}, 1),
+	)
+
+	draws = 0
+	generator.Generate()
+	if draws != DefaultIdLength {
+		t.Fatalf("Expected only the salt to draw %v random values, but got %v", DefaultIdLength, draws)
+	}
+
+	if _, err := Init(WithDeterministicFirstLetter(), WithUnambiguousAlphabet()); err == nil {
+		t.Fatalf("Expected to receive an error when combining deterministic first letter and non-base36 encodings, but got nothing")
+	}
+}
+
+func TestWithoutFirstLetterRandomness(t *testing.T) {
+	draws := 0
+	generator, err := NewGenerator(
+		WithoutFirstLetterRandomness(),
+		WithRandomFuncSamples(func() float64 {
+			draws++
+			return 0.5
+		}, 1),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	draws = 0
+	for i := 0; i < 10; i++ {
+		if cuid := generator.Generate(); cuid[:1] != fixedFirstLetter || !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid starting with %v, but got %v", fixedFirstLetter, cuid)
+		}
+	}
+
+	if draws != 10*DefaultIdLength {
+		t.Fatalf("Expected only the salt to draw random values, but got %v draws", draws)
+	}
+
+	if _, err := Init(WithoutFirstLetterRandomness(), WithDeterministicFirstLetter()); err == nil {
+		t.Fatalf("Expected to receive an error when combining fixed and deterministic first letters, but got nothing")
+	}
+}
+
+func TestDeterministicFirstLetterDistribution(t *testing.T) {
+	counts := map[string]int{}
+	n := 26000
+
+	for i := 0; i < n; i++ {
+		counts[createDeterministicFirstLetter(Hash(strconv.Itoa(i)))]++
+	}
+
+	// Roughly 1000 per letter are expected from an unbiased mapping
+	for _, letter := range Alphabet {
+		if count := counts[string(letter)]; count < 850 || count > 1150 {
+			t.Fatalf("Expected the count for %c to be close to 1000, but got %v", letter, count)
+		}
+	}
+
+	if letter := createDeterministicFirstLetter("a0123456789"); letter != "j" {
+		t.Fatalf("Expected a digest without letters to fall back to a letter, but got %v", letter)
+	}
+}
@@ -0,0 +1,99 @@
+package cuid2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of characters reserved at the end of a Cuid for the format header
+const formatHeaderLength = 1
+
+// A set of features used to generate a Cuid, as described by its format header
+type Features int
+
+const (
+	// Generated with WithSortableDescending
+	FeatureSortable Features = 1 << iota
+
+	// Generated with WithDateBucketPrefix
+	FeatureDateBucket
+
+	// Generated with WithFingerprintMarker
+	FeatureFingerprintMarker
+
+	// Generated with WithRecoverableSequence
+	FeatureRecoverableSequence
+
+	// Generated with WithChecksumLength, of either length
+	FeatureChecksum
+
+	allFeatures = FeatureSortable | FeatureDateBucket | FeatureFingerprintMarker |
+		FeatureRecoverableSequence | FeatureChecksum
+)
+
+// Checks whether all of the given features are present
+func (f Features) Has(features Features) bool {
+	return f&features == features
+}
+
+// Appends a format header to each Cuid, a single base36 character encoding a
+// bitmask of the enabled features, so that consumers of Cuids in various
+// formats can detect how each was generated with FeaturesOf.
+//
+// The header is always the last character, which costs one character of
+// entropy and raises the minimum length by one. Trailing features, such as the
+// checksum, recoverable sequence and fingerprint marker, are placed just
+// before the header, so the functions that read them, e.g. VerifyChecksum,
+// must be given the Cuid without its last character. Cannot be combined with
+// non-base36 encodings.
+func WithFormatHeader() Option {
+	return func(config *Config) error {
+		config.FormatHeader = true
+		return nil
+	}
+}
+
+// Decodes the features from the format header of a Cuid generated with
+// WithFormatHeader.
+//
+// The header cannot be told apart from a random character, so Cuids generated
+// without a header yield arbitrary features, or an error when the last
+// character does not encode a known set of features.
+func FeaturesOf(cuid string) (Features, error) {
+	if !IsCuid(cuid) {
+		return 0, fmt.Errorf("Error: Cuid (%v) is not valid", cuid)
+	}
+
+	features := Features(strings.IndexByte(Base36Digits, cuid[len(cuid)-1]))
+	if features&^allFeatures != 0 {
+		return 0, fmt.Errorf("Error: Cuid (%v) does not have a valid format header", cuid)
+	}
+
+	return features, nil
+}
+
+func createFormatHeader(config *Config) string {
+	var features Features
+
+	if config.SortableDescending {
+		features |= FeatureSortable
+	}
+
+	if config.DateBucketUnit > 0 {
+		features |= FeatureDateBucket
+	}
+
+	if config.FingerprintMarker {
+		features |= FeatureFingerprintMarker
+	}
+
+	if config.RecoverableSequence {
+		features |= FeatureRecoverableSequence
+	}
+
+	if config.ChecksumLength > 0 {
+		features |= FeatureChecksum
+	}
+
+	return string(Base36Digits[features])
+}
@@ -0,0 +1,47 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestFormatHeader(t *testing.T) {
+	generator, err := NewGenerator(WithFormatHeader(), WithSortableDescending(), WithChecksumLength(2))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	cuid := generator.Generate()
+	if len(cuid) != DefaultIdLength || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with a format header, but got %v", cuid)
+	}
+
+	features, err := FeaturesOf(cuid)
+	if err != nil {
+		t.Fatalf("Expected to decode features but received error = %v", err.Error())
+	}
+
+	if features != FeatureSortable|FeatureChecksum {
+		t.Fatalf("Expected features to be sortable and checksum, but got %v", features)
+	}
+
+	if features.Has(FeatureDateBucket) {
+		t.Fatalf("Expected features not to include a date bucket")
+	}
+
+	if !VerifyChecksum(cuid[:len(cuid)-formatHeaderLength], 2) {
+		t.Fatalf("Expected the checksum to precede the format header in %v", cuid)
+	}
+
+	plain, _ := NewGenerator(WithFormatHeader())
+	if features, _ := FeaturesOf(plain.Generate()); features != 0 {
+		t.Fatalf("Expected no features for a default Cuid, but got %v", features)
+	}
+
+	if _, err := FeaturesOf("aaaaaz"); err == nil {
+		t.Fatalf("Expected to receive an error for an unknown format header, but got nothing")
+	}
+
+	if _, err := Init(WithFormatHeader(), WithUnambiguousAlphabet()); err == nil {
+		t.Fatalf("Expected to receive an error when combining format header and non-base36 encodings, but got nothing")
+	}
+}
@@ -0,0 +1,73 @@
+package cuid2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A component of the input that is hashed to produce a Cuid
+type Component int
+
+const (
+	// The timestamp of the generation
+	TimeComponent Component = iota
+
+	// The random salt
+	SaltComponent
+
+	// The session count
+	CounterComponent
+
+	// The fingerprint
+	FingerprintComponent
+
+	componentCount = iota
+)
+
+// Configures the order in which the time, salt, counter and fingerprint are
+// concatenated into the hash input, e.g. for experimenting with the
+// distribution of generated Cuids.
+//
+// The order must contain each component exactly once. By default, the order is
+// time, salt, counter and then fingerprint. This is an advanced option, most
+// users should not need it.
+func WithHashInputOrder(order []Component) Option {
+	return func(config *Config) error {
+		if len(order) != componentCount {
+			return fmt.Errorf("Error: the hash input order must contain exactly %v components", componentCount)
+		}
+
+		seen := map[Component]bool{}
+		for _, component := range order {
+			if component < 0 || component >= componentCount {
+				return fmt.Errorf("Error: unknown hash input component (%v)", component)
+			}
+			if seen[component] {
+				return fmt.Errorf("Error: hash input component (%v) must appear exactly once", component)
+			}
+			seen[component] = true
+		}
+
+		config.HashInputOrder = append([]Component(nil), order...)
+		return nil
+	}
+}
+
+// Concatenates the components of the hash input in the given order
+func createHashInput(order []Component, time, salt, count, fingerprint string) string {
+	var hashInput strings.Builder
+	for _, component := range order {
+		switch component {
+		case TimeComponent:
+			hashInput.WriteString(time)
+		case SaltComponent:
+			hashInput.WriteString(salt)
+		case CounterComponent:
+			hashInput.WriteString(count)
+		case FingerprintComponent:
+			hashInput.WriteString(fingerprint)
+		}
+	}
+
+	return hashInput.String()
+}
@@ -0,0 +1,39 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestHashInputOrder(t *testing.T) {
+	defaultOrder := []Component{TimeComponent, SaltComponent, CounterComponent, FingerprintComponent}
+	reversedOrder := []Component{FingerprintComponent, CounterComponent, SaltComponent, TimeComponent}
+
+	unordered, _ := NewReproducible(42)
+	ordered, err := NewReproducible(42, WithHashInputOrder(defaultOrder))
+	if err != nil {
+		t.Fatalf("Expected to initialize reproducible generator but received error = %v", err.Error())
+	}
+	reversed, _ := NewReproducible(42, WithHashInputOrder(reversedOrder))
+
+	expected := unordered.Generate()
+	if actual := ordered.Generate(); actual != expected {
+		t.Fatalf("Expected the default hash input order to yield %v, but got %v", expected, actual)
+	}
+
+	if reversed.Generate() == expected {
+		t.Fatalf("Expected a different hash input order to yield a different Cuid")
+	}
+
+	invalidOrders := [][]Component{
+		nil,
+		{TimeComponent, SaltComponent, CounterComponent},
+		{TimeComponent, TimeComponent, CounterComponent, FingerprintComponent},
+		{TimeComponent, SaltComponent, CounterComponent, Component(42)},
+	}
+
+	for _, order := range invalidOrders {
+		if _, err := Init(WithHashInputOrder(order)); err == nil {
+			t.Fatalf("Expected to receive an error for Init(WithHashInputOrder(%v)), but got nothing", order)
+		}
+	}
+}
@@ -0,0 +1,32 @@
+package cuid2
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// Derives a deterministic, Cuid-shaped idempotency key from the given input,
+// the current time window and the generator's fingerprint, so that retries of
+// the same logical request within a window map to the same key.
+//
+// Windows are aligned to the Unix epoch rather than to the first request, so
+// two retries that straddle a window boundary, e.g. 12:59:59 and 13:00:00 for
+// an hourly window, yield different keys even if they are a second apart.
+// Keys also change when the fingerprint is rotated. Unlike Generate, the key
+// carries no randomness, so it must not be used where an unpredictable id is
+// required.
+func (g *Generator) IdempotencyKey(input string, window time.Duration) (string, error) {
+	if window <= 0 {
+		return "", fmt.Errorf("Error: the idempotency window must be greater than 0")
+	}
+
+	// Offset like the timestamps of generated Cuids, so that both agree on the
+	// corrected time
+	now := g.config.TimeFunc().Add(g.config.TimeOffset)
+	bucket := now.UnixNano() / int64(window)
+	fingerprint := g.fingerprint.Load().(string)
+	digest := Hash(input + strconv.FormatInt(bucket, 36) + fingerprint)
+
+	return createDeterministicFirstLetter(digest) + digest[1:g.config.Length], nil
+}
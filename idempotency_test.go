@@ -0,0 +1,69 @@
+package cuid2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestIdempotencyKey(t *testing.T) {
+	window := time.Hour
+	windowStart := time.Date(2024, 10, 26, 13, 0, 0, 0, time.UTC)
+	now := windowStart
+
+	generator, err := NewGenerator(
+		WithFingerprint("node"),
+		WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	first, err := generator.IdempotencyKey("request-1", window)
+	if err != nil {
+		t.Fatalf("Expected to derive idempotency key but received error = %v", err.Error())
+	}
+
+	if len(first) != DefaultIdLength || !IsCuid(first) {
+		t.Fatalf("Expected idempotency key to be a valid Cuid, but got %v", first)
+	}
+
+	now = windowStart.Add(window - time.Nanosecond)
+	if retried, _ := generator.IdempotencyKey("request-1", window); retried != first {
+		t.Fatalf("Expected retries within the same window to yield %v, but got %v", first, retried)
+	}
+
+	if other, _ := generator.IdempotencyKey("request-2", window); other == first {
+		t.Fatalf("Expected different inputs to yield different keys")
+	}
+
+	now = windowStart.Add(window)
+	if nextWindow, _ := generator.IdempotencyKey("request-1", window); nextWindow == first {
+		t.Fatalf("Expected the next window to yield a different key")
+	}
+
+	if _, err := generator.IdempotencyKey("request-1", 0); err == nil {
+		t.Fatalf("Expected to receive an error for a zero window, but got nothing")
+	}
+}
+
+func TestIdempotencyKeyWithTimeOffset(t *testing.T) {
+	window := time.Hour
+	windowStart := time.Date(2024, 10, 26, 13, 0, 0, 0, time.UTC)
+
+	skewed, _ := NewGenerator(
+		WithFingerprint("node"),
+		WithTimeFunc(func() time.Time { return windowStart.Add(-time.Minute) }),
+		WithTimeOffset(time.Minute),
+	)
+	corrected, _ := NewGenerator(
+		WithFingerprint("node"),
+		WithTimeFunc(func() time.Time { return windowStart }),
+	)
+
+	skewedKey, _ := skewed.IdempotencyKey("request-1", window)
+	correctedKey, _ := corrected.IdempotencyKey("request-1", window)
+
+	if skewedKey != correctedKey {
+		t.Fatalf("Expected the time offset to be applied to the idempotency window, but got %v and %v", skewedKey, correctedKey)
+	}
+}
@@ -0,0 +1,45 @@
+package cuid2
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Returns the Cuid as a quoted JSON string
+func (c CUID) MarshalJSON() ([]byte, error) {
+	return json.Marshal(string(c))
+}
+
+// Reads a Cuid from a JSON string, rejecting values that are not valid Cuids.
+//
+// A JSON null is a no-op, as for the standard library types, so that optional
+// fields can be left unset.
+func (c *CUID) UnmarshalJSON(data []byte) error {
+	if string(data) == "null" {
+		return nil
+	}
+
+	var value string
+	if err := json.Unmarshal(data, &value); err != nil {
+		return fmt.Errorf("Error: cannot unmarshal %s into a Cuid: %w", data, err)
+	}
+
+	cuid, err := Parse(value)
+	if err != nil {
+		return err
+	}
+
+	*c = cuid
+
+	return nil
+}
+
+// Generates a new Cuid as a quoted JSON string, e.g. for handlers that respond
+// with a bare id
+func (g *Generator) GenerateJSON() []byte {
+	cuid := g.Generate()
+
+	// Cuids only contain lowercase letters and digits, so they never need
+	// escaping
+	return []byte(`"` + cuid + `"`)
+}
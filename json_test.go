@@ -0,0 +1,61 @@
+package cuid2
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestCuidMarshalAndUnmarshalJSON(t *testing.T) {
+	cuid := CUID(Generate())
+
+	data, err := json.Marshal(map[string]CUID{"id": cuid})
+	if err != nil {
+		t.Fatalf("Expected to marshal Cuid but received error = %v", err.Error())
+	}
+
+	if expected := `{"id":"` + string(cuid) + `"}`; string(data) != expected {
+		t.Fatalf("Expected marshalled Cuid to be %v, but got %s", expected, data)
+	}
+
+	var unmarshalled map[string]CUID
+	if err := json.Unmarshal(data, &unmarshalled); err != nil {
+		t.Fatalf("Expected to unmarshal Cuid but received error = %v", err.Error())
+	}
+
+	if unmarshalled["id"] != cuid {
+		t.Fatalf("Expected unmarshalled Cuid to be %v, but got %v", cuid, unmarshalled["id"])
+	}
+
+	var invalid CUID
+	if err := json.Unmarshal([]byte(`"aaaaDLL"`), &invalid); err == nil {
+		t.Fatalf("Expected to receive an error when unmarshalling an invalid Cuid, but got nothing")
+	}
+
+	if err := json.Unmarshal([]byte(`42`), &invalid); err == nil {
+		t.Fatalf("Expected to receive an error when unmarshalling a non-string, but got nothing")
+	}
+
+	var optional struct {
+		ID *CUID `json:"id"`
+	}
+	if err := json.Unmarshal([]byte(`{"id":null}`), &optional); err != nil || optional.ID != nil {
+		t.Fatalf("Expected a null optional Cuid to be left unset, but got %v and error = %v", optional.ID, err)
+	}
+
+	existing := cuid
+	if err := existing.UnmarshalJSON([]byte(`null`)); err != nil || existing != cuid {
+		t.Fatalf("Expected unmarshalling null to be a no-op, but got %v and error = %v", existing, err)
+	}
+}
+
+func TestGenerateJSON(t *testing.T) {
+	generator, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	var cuid CUID
+	if err := json.Unmarshal(generator.GenerateJSON(), &cuid); err != nil {
+		t.Fatalf("Expected to unmarshal generated JSON but received error = %v", err.Error())
+	}
+}
@@ -0,0 +1,42 @@
+package cuid2
+
+import (
+	"strings"
+)
+
+// Number of characters reserved at the end of a Cuid for the fingerprint
+// marker
+const fingerprintMarkerLength = 2
+
+// Embeds a short tag derived from the hashed fingerprint at the end of each
+// Cuid, so that FingerprintMatches can later check whether a Cuid is
+// consistent with a given fingerprint, e.g. when debugging collisions across
+// nodes.
+//
+// This is opt-in, as the marker replaces the last 2 random characters of each
+// Cuid, reducing its entropy. The fingerprint itself cannot be recovered from
+// the marker. The marker is derived from the fingerprint the generator was
+// created with, so it is unaffected by fingerprint rotation.
+func WithFingerprintMarker() Option {
+	return func(config *Config) error {
+		config.FingerprintMarker = true
+		return nil
+	}
+}
+
+// Checks whether a Cuid generated with WithFingerprintMarker carries the marker
+// of the given fingerprint.
+//
+// With 2 base36 characters, roughly 1 in 1296 Cuids from an unrelated
+// fingerprint will also match, so a match is indicative rather than proof.
+func FingerprintMatches(cuid, fingerprint string) bool {
+	if !IsCuid(cuid) || len(cuid) <= fingerprintMarkerLength {
+		return false
+	}
+
+	return strings.HasSuffix(cuid, createFingerprintMarker(fingerprint))
+}
+
+func createFingerprintMarker(fingerprint string) string {
+	return Hash(fingerprint)[1 : fingerprintMarkerLength+1]
+}
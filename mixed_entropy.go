@@ -0,0 +1,54 @@
+package cuid2
+
+import (
+	"fmt"
+)
+
+// Number of bits of precision of a float64 between 0 and 1
+const mixedEntropyBits = 53
+
+// Combines multiple random functions into one, e.g. crypto/rand with a
+// hardware token, so that a weakness in one source does not weaken the Cuids.
+//
+// Each value is mixed by scaling the output of every source to a 53-bit
+// integer, XORing the integers together and scaling the result back to a
+// value between 0 and 1. As long as the sources are independent, the result is
+// at least as unpredictable as the strongest source. Each source is sampled
+// DefaultRandomFuncSamples times and rejected if any sample is out of range,
+// and the mixed function is then validated as with WithRandomFunc.
+func WithMixedEntropy(sources ...func() float64) Option {
+	return func(config *Config) error {
+		if len(sources) == 0 {
+			return fmt.Errorf("Error: at least one entropy source must be provided")
+		}
+
+		for index, source := range sources {
+			if source == nil {
+				return fmt.Errorf("Error: entropy source %v must not be nil", index)
+			}
+
+			for sample := 0; sample < DefaultRandomFuncSamples; sample++ {
+				if randomness := source(); randomness < 0 || randomness > 1 {
+					return fmt.Errorf("Error: entropy source %v does not generate a value between 0 and 1", index)
+				}
+			}
+		}
+
+		return WithRandomFunc(mixEntropy(sources))(config)
+	}
+}
+
+func mixEntropy(sources []func() float64) func() float64 {
+	const scale = 1 << mixedEntropyBits
+	const mask = scale - 1
+
+	return func() float64 {
+		var mixed uint64
+		for _, source := range sources {
+			// Masked, so that a value of exactly 1 wraps around to 0
+			mixed ^= uint64(source()*scale) & mask
+		}
+
+		return float64(mixed) / scale
+	}
+}
@@ -0,0 +1,50 @@
+package cuid2
+
+import (
+	"math/rand"
+	"testing"
+)
+
+func TestMixedEntropy(t *testing.T) {
+	constant := func() float64 { return 0.5 }
+
+	generator, err := NewGenerator(WithMixedEntropy(rand.Float64, constant))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if cuid := generator.Generate(); !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid, but got %v", cuid)
+	}
+
+	mixed := mixEntropy([]func() float64{rand.Float64, constant})
+	for i := 0; i < 1000; i++ {
+		if randomness := mixed(); randomness < 0 || randomness >= 1 {
+			t.Fatalf("Expected mixed values to be between 0 and 1, but got %v", randomness)
+		}
+	}
+
+	if value := mixEntropy([]func() float64{constant, constant})(); value != 0 {
+		t.Fatalf("Expected mixing a source with itself to cancel out, but got %v", value)
+	}
+
+	if value := mixEntropy([]func() float64{func() float64 { return 1 }})(); value != 0 {
+		t.Fatalf("Expected a value of 1 to wrap around to 0, but got %v", value)
+	}
+
+	if _, err := Init(WithMixedEntropy()); err == nil {
+		t.Fatalf("Expected to receive an error when no sources are provided, but got nothing")
+	}
+
+	if _, err := Init(WithMixedEntropy(rand.Float64, nil)); err == nil {
+		t.Fatalf("Expected to receive an error for a nil source, but got nothing")
+	}
+
+	if _, err := Init(WithMixedEntropy(rand.Float64, func() float64 { return 2 })); err == nil {
+		t.Fatalf("Expected to receive an error for an out of range source, but got nothing")
+	}
+
+	if _, err := Init(WithMixedEntropy(constant, constant)); err == nil {
+		t.Fatalf("Expected to receive an error when the mixed function is constant, but got nothing")
+	}
+}
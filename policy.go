@@ -0,0 +1,53 @@
+package cuid2
+
+import (
+	"fmt"
+)
+
+// A set of security parameters for Cuid generation, e.g. loaded from a
+// central security configuration, applied at once with WithPolicy
+type Policy struct {
+	// Length of the generated Cuids, within MinIdLength and MaxIdLength
+	Length int
+
+	// Whether the default fingerprint may be derived from the environment
+	// variables of the host. When false, it is derived from randomness alone,
+	// so that no host details enter the Cuids.
+	AllowEnvironmentFingerprint bool
+
+	// Minimum number of random characters in the salt, disabled when zero
+	MinEntropyLength int
+}
+
+// Applies all the parameters of a security policy at once, validating the
+// policy as a whole before any of it is applied.
+//
+// A policy that disallows environment fingerprinting cannot be combined with
+// WithStableFingerprintKeys or WithSharedFingerprint. Fingerprints provided by
+// other options, such as WithFingerprint, take precedence over the policy.
+func WithPolicy(policy Policy) Option {
+	return func(config *Config) error {
+		if policy.Length < MinIdLength || policy.Length > MaxIdLength {
+			return fmt.Errorf("Error: the policy length must be between %v and %v", MinIdLength, MaxIdLength)
+		}
+
+		if policy.MinEntropyLength < 0 {
+			return fmt.Errorf("Error: the policy minimum entropy length cannot be negative")
+		}
+
+		options := []Option{WithLength(policy.Length)}
+		if policy.MinEntropyLength > 0 {
+			options = append(options, WithMinEntropyLength(policy.MinEntropyLength))
+		}
+
+		for _, option := range options {
+			if err := option(config); err != nil {
+				return err
+			}
+		}
+
+		config.EnvironmentFingerprintDisallowed = !policy.AllowEnvironmentFingerprint
+
+		return nil
+	}
+}
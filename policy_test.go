@@ -0,0 +1,73 @@
+package cuid2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestPolicy(t *testing.T) {
+	generator, err := NewGenerator(WithPolicy(Policy{Length: 16, MinEntropyLength: 48}))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if cuid := generator.Generate(); len(cuid) != 16 || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid with a length of 16, but got %v", cuid)
+	}
+
+	if salt, _ := generator.createSalt(); len(salt) != 48 {
+		t.Fatalf("Expected the salt to have the policy's minimum length of 48, but got %v", len(salt))
+	}
+
+	if !generator.config.EnvironmentFingerprintDisallowed {
+		t.Fatalf("Expected environment fingerprinting to be disallowed by default")
+	}
+
+	allowed, _ := NewGenerator(WithPolicy(Policy{Length: 16, AllowEnvironmentFingerprint: true}))
+	if allowed.config.EnvironmentFingerprintDisallowed {
+		t.Fatalf("Expected environment fingerprinting to be allowed by the policy")
+	}
+
+	if _, err := Init(WithPolicy(Policy{Length: 16}), WithStableFingerprintKeys("HOSTNAME")); err == nil {
+		t.Fatalf("Expected to receive an error when combining a policy that disallows environment fingerprinting with stable keys, but got nothing")
+	}
+
+	if _, err := Init(WithPolicy(Policy{Length: 16}), WithSharedFingerprint()); err == nil {
+		t.Fatalf("Expected to receive an error when combining a policy that disallows environment fingerprinting with a shared fingerprint, but got nothing")
+	}
+
+	if _, err := Init(WithSharedFingerprint(), WithPolicy(Policy{Length: 16})); err == nil {
+		t.Fatalf("Expected to receive an error when combining a shared fingerprint with a policy that disallows environment fingerprinting, but got nothing")
+	}
+
+	rotating, err := NewGenerator(WithPolicy(Policy{Length: 16}), WithFingerprintRotation(time.Hour))
+	if err != nil {
+		t.Fatalf("Expected a policy to be combinable with fingerprint rotation, but received error = %v", err.Error())
+	}
+	rotating.Close()
+
+	first, _ := NewReproducible(42, WithPolicy(Policy{Length: 16}))
+	second, _ := NewReproducible(42, WithPolicy(Policy{Length: 16}))
+	if first.Config().Fingerprint != second.Config().Fingerprint || first.Generate() != second.Generate() {
+		t.Fatalf("Expected reproducible generators under a policy to yield identical Cuids")
+	}
+
+	constant := func() float64 { return 0.5 }
+	firstSeeded, _ := NewGenerator(WithRandomFuncSamples(constant, 1), WithPolicy(Policy{Length: 16}))
+	secondSeeded, _ := NewGenerator(WithRandomFuncSamples(constant, 1), WithPolicy(Policy{Length: 16}))
+	if firstSeeded.Config().Fingerprint == secondSeeded.Config().Fingerprint {
+		t.Fatalf("Expected the fingerprint under a policy not to be derived from the configured random function")
+	}
+
+	invalidPolicies := []Policy{
+		{},
+		{Length: MaxIdLength + 1},
+		{Length: 16, MinEntropyLength: -1},
+	}
+
+	for _, policy := range invalidPolicies {
+		if _, err := Init(WithPolicy(policy)); err == nil {
+			t.Fatalf("Expected to receive an error for an invalid policy (%+v), but got nothing", policy)
+		}
+	}
+}
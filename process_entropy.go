@@ -0,0 +1,37 @@
+package cuid2
+
+import (
+	"fmt"
+	"os"
+	"sync"
+	"time"
+)
+
+var (
+	processEntropyOnce sync.Once
+	processEntropy     string
+)
+
+// Folds a process-unique value into the fingerprint, to further reduce the
+// risk of collisions between processes that are configured identically, e.g.
+// replicas started from the same image with the same environment.
+//
+// The value combines the process id, the address of a heap allocation and the
+// time, and is captured once per process. This is a best-effort measure that
+// is neither cryptographic nor guaranteed to be unique on its own, and makes
+// the fingerprint non-deterministic across restarts.
+func WithProcessMemoryEntropy() Option {
+	return func(config *Config) error {
+		config.ProcessMemoryEntropy = true
+		return nil
+	}
+}
+
+func getProcessEntropy() string {
+	processEntropyOnce.Do(func() {
+		marker := new(int64)
+		processEntropy = fmt.Sprintf("%d%p%d", os.Getpid(), marker, time.Now().UnixNano())
+	})
+
+	return processEntropy
+}
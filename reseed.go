@@ -0,0 +1,84 @@
+package cuid2
+
+import (
+	cryptorand "crypto/rand"
+	"encoding/binary"
+	"fmt"
+	"math/rand"
+	"sync"
+	"time"
+)
+
+// A random source that is safe for concurrent use, so that it can be reseeded
+// while Cuids are being generated
+type lockedSource struct {
+	mutex  sync.Mutex
+	source rand.Source
+}
+
+func (ls *lockedSource) Int63() int64 {
+	ls.mutex.Lock()
+	defer ls.mutex.Unlock()
+	return ls.source.Int63()
+}
+
+func (ls *lockedSource) Seed(seed int64) {
+	ls.mutex.Lock()
+	defer ls.mutex.Unlock()
+	ls.source.Seed(seed)
+}
+
+// A seedable random source, such as a PRNG, that will be used to generate the
+// random floating-point values used by the Cuid generator.
+//
+// The source is guarded by a mutex, so it does not need to be safe for
+// concurrent use itself. Unlike WithRandomFunc, the source can be periodically
+// reseeded with WithReseedInterval.
+func WithRandomSource(source rand.Source) Option {
+	return func(config *Config) error {
+		if source == nil {
+			return fmt.Errorf("Error: the provided random source must not be nil")
+		}
+		seedableSource := &lockedSource{source: source}
+		config.RandomFunc = rand.New(seedableSource).Float64
+		config.customRandomFunc = true
+		config.seedableSource = seedableSource
+		return nil
+	}
+}
+
+// Periodically reseeds the random source provided by WithRandomSource from
+// crypto/rand, limiting how long the output of a PRNG remains predictable.
+//
+// Reseeding happens on a background goroutine, which must be stopped by
+// calling Close on the Generator to avoid leaking it. This option is a no-op
+// for random functions provided by WithRandomFunc, which cannot be reseeded.
+func WithReseedInterval(interval time.Duration) Option {
+	return func(config *Config) error {
+		if interval <= 0 {
+			return fmt.Errorf("Error: the reseed interval must be greater than 0")
+		}
+		config.ReseedInterval = interval
+		return nil
+	}
+}
+
+func (g *Generator) reseed(interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	seed := make([]byte, 8)
+
+	for {
+		select {
+		case <-g.stop:
+			return
+		case <-ticker.C:
+			// Keep the current seed if crypto/rand is unavailable
+			if _, err := cryptorand.Read(seed); err != nil {
+				continue
+			}
+			g.config.seedableSource.Seed(int64(binary.LittleEndian.Uint64(seed)))
+		}
+	}
+}
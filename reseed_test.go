@@ -0,0 +1,51 @@
+package cuid2
+
+import (
+	"math/rand"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestReseedInterval(t *testing.T) {
+	generator, err := NewGenerator(
+		WithRandomSource(rand.NewSource(42)),
+		WithReseedInterval(time.Millisecond),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	wg := new(sync.WaitGroup)
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for j := 0; j < 1000; j++ {
+				if cuid := generator.Generate(); !IsCuid(cuid) {
+					t.Errorf("Expected to generate a valid Cuid during reseeding, but got %v", cuid)
+					return
+				}
+			}
+		}()
+	}
+	wg.Wait()
+
+	generator.Close()
+
+	idle, _ := NewGenerator(WithRandomSource(rand.NewSource(42)), WithReseedInterval(time.Millisecond))
+	time.Sleep(10 * time.Millisecond)
+	idle.Close()
+
+	// Without any draws, only reseeding can make the source diverge from the
+	// original seed
+	if idle.config.seedableSource.Int63() == rand.NewSource(42).Int63() {
+		t.Fatalf("Expected the random source to have been reseeded")
+	}
+
+	nonSeedable, err := NewGenerator(WithRandomFunc(rand.Float64), WithReseedInterval(time.Millisecond))
+	if err != nil {
+		t.Fatalf("Expected reseeding to be a no-op for non-seedable sources, but received error = %v", err.Error())
+	}
+	nonSeedable.Close()
+}
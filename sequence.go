@@ -0,0 +1,49 @@
+package cuid2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Number of base36 characters reserved at the end of a Cuid for the
+// recoverable sequence number
+const sequenceWidth = 7
+
+var sequenceModulus = int64(math.Pow(36, sequenceWidth))
+
+// Appends the session count to each Cuid in a recoverable, fixed-width base36
+// form, so that the issuer can read back the exact count with SequenceOf.
+//
+// Unlike sortable mode, this recovers the exact count rather than just an
+// ordering. The sequence replaces the last 7 random characters of each Cuid,
+// wrapping around every 36^7 counts, and reveals how many Cuids the generator
+// has issued. It cannot be combined with WithFingerprintMarker.
+func WithRecoverableSequence() Option {
+	return func(config *Config) error {
+		config.RecoverableSequence = true
+		return nil
+	}
+}
+
+// Recovers the session count from a Cuid generated with
+// WithRecoverableSequence
+func SequenceOf(cuid string) (int64, error) {
+	if !IsCuid(cuid) || len(cuid) < MinIdLength+sequenceWidth {
+		return 0, fmt.Errorf("Error: Cuid (%v) does not contain a recoverable sequence", cuid)
+	}
+
+	sequence, err := strconv.ParseInt(cuid[len(cuid)-sequenceWidth:], 36, 64)
+	if err != nil {
+		return 0, fmt.Errorf("Error: could not decode sequence of Cuid (%v): %w", cuid, err)
+	}
+
+	return sequence, nil
+}
+
+func encodeSequence(sessionCount int64) string {
+	sequence := ((sessionCount % sequenceModulus) + sequenceModulus) % sequenceModulus
+	encodedSequence := strconv.FormatInt(sequence, 36)
+	return strings.Repeat("0", sequenceWidth-len(encodedSequence)) + encodedSequence
+}
@@ -0,0 +1,58 @@
+package cuid2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Number of letters available for the leading character of a Cuid
+const shardHintLetterCount = len(Alphabet)
+
+// Derives the leading letter of each Cuid from its hash, so that Cuids spread
+// evenly across the given number of shards when routed by prefix.
+//
+// The 26 letters are divided into equal, contiguous ranges of 26 / shards
+// letters, one per shard, e.g. for 4 shards "a" to "f" map to shard 0, "g" to
+// "l" to shard 1 and so on. Letters left over by the division, "y" and "z" in
+// that example, are never used. The number of shards must be between 1 and 26,
+// and a power of two is recommended for consistent hashing schemes.
+func WithShardHint(shards int) Option {
+	return func(config *Config) error {
+		if shards < 1 || shards > shardHintLetterCount {
+			return fmt.Errorf("Error: the number of shards must be between 1 and %v", shardHintLetterCount)
+		}
+		config.ShardHint = shards
+		return nil
+	}
+}
+
+// Returns the shard of a Cuid generated with WithShardHint, given the same
+// number of shards, or -1 if the Cuid is invalid or its leading letter does not
+// belong to any shard
+func ShardHintOf(cuid string, shards int) int {
+	if shards < 1 || shards > shardHintLetterCount || !IsCuid(cuid) {
+		return -1
+	}
+
+	shard := strings.IndexByte(Alphabet, cuid[0]) / (shardHintLetterCount / shards)
+	if shard >= shards {
+		return -1
+	}
+
+	return shard
+}
+
+// Returns a leading letter within the range of the shard derived from the
+// given base36 body, using the random first letter to pick within the range
+func createShardHintLetter(body string, firstLetter string, shards int) string {
+	shard := 0
+	for index := 0; index < len(body); index++ {
+		digit := strings.IndexByte(Base36Digits, body[index])
+		shard = (shard*len(Base36Digits) + digit) % shards
+	}
+
+	lettersPerShard := shardHintLetterCount / shards
+	offset := strings.IndexByte(Alphabet, firstLetter[0]) % lettersPerShard
+
+	return string(Alphabet[shard*lettersPerShard+offset])
+}
@@ -0,0 +1,49 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestShardHint(t *testing.T) {
+	shards := 4
+	generator, err := NewGenerator(WithShardHint(shards))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	counts := make([]int, shards)
+	numberOfCuids := 10000
+	for i := 0; i < numberOfCuids; i++ {
+		cuid := generator.Generate()
+		if !IsCuid(cuid) {
+			t.Fatalf("Expected to generate a valid Cuid with a shard hint, but got %v", cuid)
+		}
+
+		shard := ShardHintOf(cuid, shards)
+		if shard < 0 || shard >= shards {
+			t.Fatalf("Expected Cuid (%v) to belong to one of %v shards, but got %v", cuid, shards, shard)
+		}
+		counts[shard]++
+	}
+
+	expected := numberOfCuids / shards
+	for shard, count := range counts {
+		if count < expected*9/10 || count > expected*11/10 {
+			t.Fatalf("Expected shard %v to receive about %v Cuids, but got %v", shard, expected, count)
+		}
+	}
+
+	if shard := ShardHintOf("zzzzzzzz", shards); shard != -1 {
+		t.Fatalf("Expected a leading letter outside every shard to yield -1, but got %v", shard)
+	}
+
+	for _, invalidShards := range []int{0, -1, 27} {
+		if _, err := Init(WithShardHint(invalidShards)); err == nil {
+			t.Fatalf("Expected to receive an error for Init(WithShardHint(%v)), but got nothing", invalidShards)
+		}
+	}
+
+	if _, err := Init(WithShardHint(shards), WithSortableDescending()); err == nil {
+		t.Fatalf("Expected to receive an error when combining shard hint and sortable options, but got nothing")
+	}
+}
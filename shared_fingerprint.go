@@ -0,0 +1,45 @@
+package cuid2
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+var (
+	sharedFingerprintOnce sync.Once
+	sharedFingerprint     atomic.Value
+)
+
+// Returns a fingerprint derived once per process from random entropy and the
+// environment, in the same way as the fingerprint of a default generator
+func SharedFingerprint() string {
+	sharedFingerprintOnce.Do(func() {
+		sharedFingerprint.Store(createDefaultFingerprint())
+	})
+
+	return sharedFingerprint.Load().(string)
+}
+
+// Checks whether the given fingerprint is the SharedFingerprint, without
+// deriving it if it has not been derived yet
+func isSharedFingerprint(fingerprint string) bool {
+	shared, derived := sharedFingerprint.Load().(string)
+	return derived && fingerprint == shared
+}
+
+// Uses the process-wide SharedFingerprint rather than deriving a new
+// fingerprint for the generator, which makes creating many generators in one
+// process, e.g. one per tenant, considerably cheaper.
+//
+// Generators sharing a fingerprint only differ by their session counters and
+// salts, so isolation between them relies on those, or on options that fold
+// extra values into the fingerprint, such as WithShardId. TenantGenerator
+// still folds each tenant id into the shared fingerprint. Since the shared
+// fingerprint is derived from the environment, it cannot be combined with a
+// policy that disallows environment fingerprinting.
+func WithSharedFingerprint() Option {
+	return func(config *Config) error {
+		config.Fingerprint = SharedFingerprint()
+		return nil
+	}
+}
@@ -0,0 +1,12 @@
+//go:build go1.21
+
+package cuid2
+
+import (
+	"log/slog"
+)
+
+// Returns the Cuid as a string value for structured logging
+func (c CUID) LogValue() slog.Value {
+	return slog.StringValue(string(c))
+}
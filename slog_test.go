@@ -0,0 +1,27 @@
+//go:build go1.21
+
+package cuid2
+
+import (
+	"bytes"
+	"encoding/json"
+	"log/slog"
+	"testing"
+)
+
+func TestCuidLogValue(t *testing.T) {
+	cuid := CUID(Generate())
+
+	buffer := new(bytes.Buffer)
+	logger := slog.New(slog.NewJSONHandler(buffer, nil))
+	logger.Info("generated", "id", cuid)
+
+	record := map[string]any{}
+	if err := json.Unmarshal(buffer.Bytes(), &record); err != nil {
+		t.Fatalf("Expected to decode log record but received error = %v", err.Error())
+	}
+
+	if record["id"] != string(cuid) {
+		t.Fatalf("Expected logged id attribute to be %v, but got %v", cuid, record["id"])
+	}
+}
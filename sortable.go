@@ -0,0 +1,80 @@
+package cuid2
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// Leading letter that marks a Cuid as descending-sortable
+	descendingSortableMarker = "d"
+
+	// Number of base36 characters used to encode the millisecond timestamp of
+	// a sortable Cuid, enough to represent times up to the year ~5138
+	sortableTimestampWidth = 9
+
+	// Minimum number of random characters that follow the timestamp of a
+	// sortable Cuid
+	minSortableEntropyLength = 4
+
+	MinSortableIdLength int = len(descendingSortableMarker) + sortableTimestampWidth + minSortableEntropyLength
+)
+
+var maxSortableTimestamp = int64(math.Pow(36, sortableTimestampWidth)) - 1
+
+// Generates Cuids that sort lexically in descending order of time, i.e. newest
+// first, which is useful for feeds that use the id as a cursor.
+//
+// The Cuid starts with the marker letter "d", followed by a fixed-width base36
+// encoding of the maximum timestamp minus the current millisecond timestamp.
+// The remaining characters are random. Cuids generated within the same
+// millisecond are not ordered relative to one another. Generation fails with
+// an error for times outside the encodable range, i.e. before the Unix epoch or
+// after the year ~5138.
+//
+// Requires a length of at least MinSortableIdLength
+func WithSortableDescending() Option {
+	return func(config *Config) error {
+		config.SortableDescending = true
+		return nil
+	}
+}
+
+// Extracts the millisecond-precision time at which a sortable Cuid was
+// generated.
+//
+// The marker cannot be told apart from a random first letter, so whether a
+// Cuid was generated in sortable mode cannot be detected. Any Cuid that starts
+// with "d" is decoded, and one generated without the option yields an
+// arbitrary time within the encodable range rather than an error.
+func ExtractTime(cuid string) (time.Time, error) {
+	if !IsCuid(cuid) || len(cuid) < MinSortableIdLength {
+		return time.Time{}, fmt.Errorf("Error: Cuid (%v) is not a valid sortable Cuid", cuid)
+	}
+
+	if !strings.HasPrefix(cuid, descendingSortableMarker) {
+		return time.Time{}, fmt.Errorf("Error: Cuid (%v) does not start with the sortable marker", cuid)
+	}
+
+	encodedTimestamp := cuid[len(descendingSortableMarker) : len(descendingSortableMarker)+sortableTimestampWidth]
+	timestamp, err := strconv.ParseInt(encodedTimestamp, 36, 64)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("Error: could not decode timestamp of Cuid (%v): %w", cuid, err)
+	}
+
+	return time.UnixMilli(maxSortableTimestamp - timestamp), nil
+}
+
+func createDescendingTimePrefix(now time.Time) (string, error) {
+	milliseconds := now.UnixMilli()
+	if milliseconds < 0 || milliseconds > maxSortableTimestamp {
+		return "", fmt.Errorf("Error: the time (%v) is outside the range of a sortable Cuid", now)
+	}
+
+	timestamp := strconv.FormatInt(maxSortableTimestamp-milliseconds, 36)
+	padding := strings.Repeat("0", sortableTimestampWidth-len(timestamp))
+	return descendingSortableMarker + padding + timestamp, nil
+}
@@ -0,0 +1,107 @@
+package cuid2
+
+import (
+	"testing"
+	"time"
+)
+
+func TestSortableDescending(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+
+	generator, err := NewGenerator(
+		WithSortableDescending(),
+		WithTimeFunc(func() time.Time { return now }),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	earlier := generator.Generate()
+	now = now.Add(time.Millisecond)
+	later := generator.Generate()
+
+	if len(later) != DefaultIdLength || !IsCuid(later) {
+		t.Fatalf("Expected to generate a valid sortable Cuid, but got %v", later)
+	}
+
+	if later >= earlier {
+		t.Fatalf("Expected later Cuid (%v) to sort before earlier Cuid (%v)", later, earlier)
+	}
+
+	extractedTime, err := ExtractTime(later)
+	if err != nil {
+		t.Fatalf("Expected to extract time but received error = %v", err.Error())
+	}
+
+	if !extractedTime.Equal(now) {
+		t.Fatalf("Expected extracted time to be %v, but got %v", now, extractedTime)
+	}
+
+	if _, err := ExtractTime("a" + later[1:]); err == nil {
+		t.Fatalf("Expected to receive an error for a non-sortable Cuid, but got nothing")
+	}
+
+	if _, err := Init(WithSortableDescending(), WithLength(MinSortableIdLength-1)); err == nil {
+		t.Fatalf("Expected to receive an error for a sortable Cuid that is too short, but got nothing")
+	}
+}
+
+func TestSortableWithTimeOffset(t *testing.T) {
+	now := time.UnixMilli(1700000000000)
+	offset := -250 * time.Millisecond
+
+	generator, err := NewGenerator(
+		WithSortableDescending(),
+		WithTimeFunc(func() time.Time { return now }),
+		WithTimeOffset(offset),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	extractedTime, err := ExtractTime(generator.Generate())
+	if err != nil {
+		t.Fatalf("Expected to extract time but received error = %v", err.Error())
+	}
+
+	if expected := now.Add(offset); !extractedTime.Equal(expected) {
+		t.Fatalf("Expected extracted time to be %v, but got %v", expected, extractedTime)
+	}
+
+	unset, _ := NewReproducible(42)
+	zeroOffset, _ := NewReproducible(42, WithTimeOffset(0))
+	if unset.Generate() != zeroOffset.Generate() {
+		t.Fatalf("Expected a zero time offset to leave Cuids unchanged")
+	}
+}
+
+func TestSortableOutOfRange(t *testing.T) {
+	now := time.UnixMilli(0)
+	clock := func() time.Time { return now }
+
+	generator, err := NewGenerator(WithSortableDescending(), WithTimeFunc(clock))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if _, err := generator.GenerateE(); err != nil {
+		t.Fatalf("Expected the Unix epoch to be within range of a sortable Cuid, but received error = %v", err.Error())
+	}
+
+	for _, outOfRange := range []time.Time{time.UnixMilli(-1), time.UnixMilli(maxSortableTimestamp + 1)} {
+		now = outOfRange
+		if cuid, err := generator.GenerateE(); err == nil {
+			t.Fatalf("Expected to receive an error for a time out of range at %v, but got %v", now, cuid)
+		}
+	}
+
+	negativeOffset, _ := NewGenerator(
+		WithSortableDescending(),
+		WithTimeFunc(func() time.Time { return time.UnixMilli(0) }),
+		WithTimeOffset(-time.Second),
+		WithNoPanic(),
+	)
+	if cuid := negativeOffset.Generate(); cuid != "" {
+		t.Fatalf("Expected an empty Cuid for a negative time offset before the Unix epoch, but got %v", cuid)
+	}
+}
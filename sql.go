@@ -0,0 +1,114 @@
+package cuid2
+
+import (
+	"database/sql/driver"
+	"fmt"
+	"strings"
+	"sync/atomic"
+)
+
+// Length of the first Cuid scanned by any ConsistentLengthScanner in the
+// process, zero until a Cuid has been scanned
+var consistentScanLength atomic.Int64
+
+// Returns the Cuid as a string value for storage in a database column
+func (c CUID) Value() (driver.Value, error) {
+	return string(c), nil
+}
+
+// Reads a Cuid from a database column, rejecting values that are not valid
+// Cuids.
+//
+// Trailing spaces are trimmed before validation, since some drivers return
+// space-padded values for fixed-width CHAR columns.
+func (c *CUID) Scan(src any) error {
+	var value string
+
+	switch src := src.(type) {
+	case string:
+		value = src
+	case []byte:
+		value = string(src)
+	default:
+		return fmt.Errorf("Error: cannot scan type %T into a Cuid", src)
+	}
+
+	value = strings.TrimRight(value, " ")
+
+	if !IsCuid(value) {
+		return fmt.Errorf("Error: scanned value (%v) is not a valid Cuid", value)
+	}
+
+	*c = CUID(value)
+
+	return nil
+}
+
+// A Cuid stored in a fixed-width CHAR column, padded with trailing spaces up
+// to Width when written
+type CharCUID struct {
+	CUID  CUID
+	Width int
+}
+
+// Returns the Cuid right-padded with spaces up to the configured width
+func (c CharCUID) Value() (driver.Value, error) {
+	if len(c.CUID) > c.Width {
+		return nil, fmt.Errorf("Error: Cuid (%v) exceeds the column width of %v", c.CUID, c.Width)
+	}
+
+	return string(c.CUID) + strings.Repeat(" ", c.Width-len(c.CUID)), nil
+}
+
+// Reads a Cuid from a fixed-width CHAR column, trimming any padding
+func (c *CharCUID) Scan(src any) error {
+	return c.CUID.Scan(src)
+}
+
+// A Cuid that, when scanned, must have the same length as the first Cuid
+// scanned by any ConsistentLengthScanner in the process, e.g. to detect data
+// that mixes Cuids of different lengths when the configured length is not
+// known up front.
+//
+// This is opt-in, use CUID for scanning without the length check. The first
+// length is recorded once per process and shared by all scanners.
+type ConsistentLengthScanner struct {
+	CUID CUID
+}
+
+// Returns the Cuid as a string value for storage in a database column
+func (c ConsistentLengthScanner) Value() (driver.Value, error) {
+	return c.CUID.Value()
+}
+
+// Reads a Cuid from a database column, rejecting values that are not valid
+// Cuids or whose length differs from the first Cuid scanned in the process
+func (c *ConsistentLengthScanner) Scan(src any) error {
+	var cuid CUID
+	if err := cuid.Scan(src); err != nil {
+		return err
+	}
+
+	length := int64(len(cuid))
+	if !consistentScanLength.CompareAndSwap(0, length) {
+		if expected := consistentScanLength.Load(); length != expected {
+			return fmt.Errorf("Error: scanned Cuid (%v) has a length of %v, but previously scanned Cuids have a length of %v", cuid, length, expected)
+		}
+	}
+
+	c.CUID = cuid
+
+	return nil
+}
+
+// Generates a new Cuid along with its single-quoted SQL string literal, e.g.
+// for building raw SQL in migration scripts.
+//
+// Cuids only contain lowercase letters and digits, but any single quotes are
+// still escaped by doubling them, so the literal is safe to embed as is.
+func (g *Generator) GenerateSQLLiteral() (id string, literal string) {
+	id = g.Generate()
+	literal = "'" + strings.ReplaceAll(id, "'", "''") + "'"
+
+	return id, literal
+}
@@ -0,0 +1,113 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestCuidValueAndScan(t *testing.T) {
+	cuid := CUID(Generate())
+
+	value, err := cuid.Value()
+	if err != nil {
+		t.Fatalf("Expected to get value of Cuid but received error = %v", err.Error())
+	}
+
+	var scanned CUID
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Expected to scan Cuid but received error = %v", err.Error())
+	}
+
+	if scanned != cuid {
+		t.Fatalf("Expected scanned Cuid to be %v, but got %v", cuid, scanned)
+	}
+
+	if err := scanned.Scan([]byte("aaaaDLL")); err == nil {
+		t.Fatalf("Expected to receive an error when scanning an invalid Cuid, but got nothing")
+	}
+
+	if err := scanned.Scan(42); err == nil {
+		t.Fatalf("Expected to receive an error when scanning an unsupported type, but got nothing")
+	}
+}
+
+func TestCharCuidValueAndScan(t *testing.T) {
+	width := 32
+	cuid := CharCUID{CUID: CUID(Generate()), Width: width}
+
+	value, err := cuid.Value()
+	if err != nil {
+		t.Fatalf("Expected to get value of Cuid but received error = %v", err.Error())
+	}
+
+	if len(value.(string)) != width {
+		t.Fatalf("Expected value to be padded to a width of %v, but got %v", width, len(value.(string)))
+	}
+
+	// Padded round-trip
+	var scanned CharCUID
+	if err := scanned.Scan(value); err != nil {
+		t.Fatalf("Expected to scan padded Cuid but received error = %v", err.Error())
+	}
+
+	if scanned.CUID != cuid.CUID {
+		t.Fatalf("Expected scanned Cuid to be %v, but got %v", cuid.CUID, scanned.CUID)
+	}
+
+	// Unpadded round-trip
+	if err := scanned.Scan(string(cuid.CUID)); err != nil {
+		t.Fatalf("Expected to scan unpadded Cuid but received error = %v", err.Error())
+	}
+
+	if scanned.CUID != cuid.CUID {
+		t.Fatalf("Expected scanned Cuid to be %v, but got %v", cuid.CUID, scanned.CUID)
+	}
+
+	tooNarrow := CharCUID{CUID: cuid.CUID, Width: MinIdLength}
+	if _, err := tooNarrow.Value(); err == nil {
+		t.Fatalf("Expected to receive an error for a Cuid exceeding the column width, but got nothing")
+	}
+}
+
+func TestConsistentLengthScanner(t *testing.T) {
+	consistentScanLength.Store(0)
+	defer consistentScanLength.Store(0)
+
+	var scanner ConsistentLengthScanner
+	first := Generate()
+	if err := scanner.Scan(first); err != nil {
+		t.Fatalf("Expected to scan Cuid but received error = %v", err.Error())
+	}
+
+	if string(scanner.CUID) != first {
+		t.Fatalf("Expected scanned Cuid to be %v, but got %v", first, scanner.CUID)
+	}
+
+	var other ConsistentLengthScanner
+	if err := other.Scan([]byte(Generate())); err != nil {
+		t.Fatalf("Expected to scan a Cuid of the same length but received error = %v", err.Error())
+	}
+
+	if err := other.Scan(first[:10]); err == nil {
+		t.Fatalf("Expected to receive an error when scanning a Cuid of a different length, but got nothing")
+	}
+
+	if err := other.Scan("aaaaDLL"); err == nil {
+		t.Fatalf("Expected to receive an error when scanning an invalid Cuid, but got nothing")
+	}
+}
+
+func TestGenerateSQLLiteral(t *testing.T) {
+	generator, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	id, literal := generator.GenerateSQLLiteral()
+	if !IsCuid(id) {
+		t.Fatalf("Expected to generate a valid Cuid, but got %v", id)
+	}
+
+	if expected := "'" + id + "'"; literal != expected {
+		t.Fatalf("Expected SQL literal to be %v, but got %v", expected, literal)
+	}
+}
@@ -0,0 +1,30 @@
+package cuid2
+
+import (
+	"context"
+)
+
+// Generates Cuids into the given channel until the context is done, returning
+// the context's error, or earlier if generation fails.
+//
+// Each send blocks until the consumer receives it, so the channel's capacity
+// provides natural backpressure. The channel is never closed by Stream, as it
+// is owned by the caller.
+func (g *Generator) Stream(ctx context.Context, out chan<- string) error {
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+
+		cuid, err := g.GenerateE()
+		if err != nil {
+			return err
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case out <- cuid:
+		}
+	}
+}
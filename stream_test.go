@@ -0,0 +1,47 @@
+package cuid2
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestStream(t *testing.T) {
+	generator, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	out := make(chan string, 4)
+	done := make(chan error)
+
+	go func() {
+		done <- generator.Stream(ctx, out)
+	}()
+
+	for i := 0; i < 10; i++ {
+		if cuid := <-out; !IsCuid(cuid) {
+			t.Fatalf("Expected to receive a valid Cuid from the stream, but got %v", cuid)
+		}
+	}
+
+	// The producer is now blocked on a full channel
+	cancel()
+
+	select {
+	case err := <-done:
+		if !errors.Is(err, context.Canceled) {
+			t.Fatalf("Expected the stream to stop with context.Canceled, but got %v", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatalf("Expected the stream to stop promptly after cancellation")
+	}
+
+	// The caller's channel is left open
+	select {
+	case out <- "":
+	default:
+	}
+}
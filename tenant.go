@@ -0,0 +1,147 @@
+package cuid2
+
+import (
+	"container/list"
+	"fmt"
+	"sync"
+)
+
+// Manages a set of Cuid generators keyed by tenant, so that Cuids are
+// namespaced per tenant for collision isolation and traceability.
+//
+// Generators are created lazily on first use, with the tenant id folded into
+// the fingerprint. Memory usage grows with the number of tenants, so a
+// maximum number of tenants can be configured, beyond which the least recently
+// used generator is evicted.
+type TenantGenerator struct {
+	mutex      sync.Mutex
+	options    []Option
+	maxTenants int
+	noPanic    bool
+	generators map[string]*list.Element
+	recency    *list.List
+}
+
+type tenantEntry struct {
+	tenantID  string
+	generator *Generator
+}
+
+// Creates a new tenant generator, applying the given options to each tenant's
+// generator.
+//
+// The options are validated up front without creating a generator, so
+// callbacks such as a fingerprint logger are only invoked for tenants. Options
+// that do their work when applied, such as WithFingerprintProvider or
+// WithFingerprintFromFile, are invoked once more by the validation.
+//
+// A maxTenants value of 0 allows an unbounded number of tenants.
+func NewTenantGenerator(maxTenants int, options ...Option) (*TenantGenerator, error) {
+	if maxTenants < 0 {
+		return nil, fmt.Errorf("Error: the maximum number of tenants cannot be negative")
+	}
+
+	config := newDefaultConfig()
+	if err := configure(config, options...); err != nil {
+		return nil, err
+	}
+
+	return &TenantGenerator{
+		options:    options,
+		maxTenants: maxTenants,
+		noPanic:    config.NoPanic,
+		generators: map[string]*list.Element{},
+		recency:    list.New(),
+	}, nil
+}
+
+// Generates a new Cuid using the generator for the given tenant
+//
+// Like Generate, it panics if the tenant's generator cannot be created, e.g.
+// when a fingerprint provider fails, or if generation fails, unless
+// WithNoPanic is set, in which case an empty string is returned. Use
+// GenerateForE to handle such failures.
+func (tg *TenantGenerator) GenerateFor(tenantID string) string {
+	generator, err := tg.generatorFor(tenantID)
+	if err != nil {
+		if tg.noPanic {
+			return ""
+		}
+		panic(err)
+	}
+
+	return generator.Generate()
+}
+
+// Generates a new Cuid using the generator for the given tenant, returning an
+// error if the tenant's generator cannot be created or if generation fails
+func (tg *TenantGenerator) GenerateForE(tenantID string) (string, error) {
+	generator, err := tg.generatorFor(tenantID)
+	if err != nil {
+		return "", err
+	}
+
+	return generator.GenerateE()
+}
+
+// Removes the generator for the given tenant, if one exists
+func (tg *TenantGenerator) Evict(tenantID string) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	if element, exists := tg.generators[tenantID]; exists {
+		tg.remove(element)
+	}
+}
+
+// Returns the number of tenants with a generator
+func (tg *TenantGenerator) Len() int {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	return len(tg.generators)
+}
+
+func (tg *TenantGenerator) generatorFor(tenantID string) (*Generator, error) {
+	tg.mutex.Lock()
+	defer tg.mutex.Unlock()
+
+	if element, exists := tg.generators[tenantID]; exists {
+		tg.recency.MoveToFront(element)
+		return element.Value.(*tenantEntry).generator, nil
+	}
+
+	options := append([]Option{}, tg.options...)
+	options = append(options, withTenantFingerprint(tenantID))
+
+	generator, err := NewGenerator(options...)
+	if err != nil {
+		return nil, err
+	}
+
+	tg.generators[tenantID] = tg.recency.PushFront(
+		&tenantEntry{tenantID: tenantID, generator: generator},
+	)
+
+	if tg.maxTenants > 0 && tg.recency.Len() > tg.maxTenants {
+		tg.remove(tg.recency.Back())
+	}
+
+	return generator, nil
+}
+
+func (tg *TenantGenerator) remove(element *list.Element) {
+	entry := tg.recency.Remove(element).(*tenantEntry)
+	delete(tg.generators, entry.tenantID)
+	entry.generator.Close()
+}
+
+// Folds the tenant id into the resolved fingerprint, preserving the
+// uniqueness of the underlying host fingerprint
+func withTenantFingerprint(tenantID string) Option {
+	return func(config *Config) error {
+		config.tenantID = tenantID
+		config.hasTenantID = true
+		return nil
+	}
+}
@@ -0,0 +1,140 @@
+package cuid2
+
+import (
+	"errors"
+	"sync"
+	"testing"
+)
+
+func TestTenantGenerator(t *testing.T) {
+	tenantGenerator, err := NewTenantGenerator(2, WithLength(16))
+	if err != nil {
+		t.Fatalf("Expected to initialize tenant generator but received error = %v", err.Error())
+	}
+
+	tenants := []string{"tenant-a", "tenant-b", "tenant-c"}
+	set := sync.Map{}
+
+	wg := new(sync.WaitGroup)
+	for _, tenant := range tenants {
+		wg.Add(1)
+		go func(tenant string) {
+			defer wg.Done()
+			for i := 0; i < 100; i++ {
+				cuid := tenantGenerator.GenerateFor(tenant)
+				if len(cuid) != 16 || !IsCuid(cuid) {
+					t.Errorf("Expected to generate a valid Cuid of length 16 for %v, but got %v", tenant, cuid)
+					return
+				}
+				if _, exists := set.LoadOrStore(cuid, struct{}{}); exists {
+					t.Errorf("Expected Cuids to be unique across tenants, but %v was repeated", cuid)
+					return
+				}
+			}
+		}(tenant)
+	}
+	wg.Wait()
+
+	if tenantGenerator.Len() != 2 {
+		t.Fatalf("Expected tenant generator to hold at most 2 tenants, but got %v", tenantGenerator.Len())
+	}
+
+	tenantGenerator.Evict("tenant-a")
+	tenantGenerator.Evict("tenant-b")
+	tenantGenerator.Evict("tenant-c")
+
+	if tenantGenerator.Len() != 0 {
+		t.Fatalf("Expected all tenants to be evicted, but got %v", tenantGenerator.Len())
+	}
+
+	if _, err := NewTenantGenerator(0, WithLength(64)); err == nil {
+		t.Fatalf("Expected to receive an error for NewTenantGenerator(0, WithLength(64)), but got nothing")
+	}
+}
+
+func TestTenantGeneratorWithStableFingerprintKeys(t *testing.T) {
+	tenantGenerator, err := NewTenantGenerator(0, WithStableFingerprintKeys("HOME"))
+	if err != nil {
+		t.Fatalf("Expected to initialize tenant generator but received error = %v", err.Error())
+	}
+
+	first, _ := tenantGenerator.generatorFor("tenant-a")
+	second, _ := tenantGenerator.generatorFor("tenant-b")
+
+	if first.Config().Fingerprint == second.Config().Fingerprint {
+		t.Fatalf("Expected tenants to have distinct fingerprints")
+	}
+}
+
+func TestTenantGeneratorErrors(t *testing.T) {
+	calls := 0
+	provider := func() (string, error) {
+		calls++
+		if calls > 1 {
+			return "", errors.New("fingerprint service unavailable")
+		}
+		return "node-1", nil
+	}
+
+	tenantGenerator, err := NewTenantGenerator(0, WithFingerprintProvider(provider))
+	if err != nil {
+		t.Fatalf("Expected to initialize tenant generator but received error = %v", err.Error())
+	}
+
+	if _, err := tenantGenerator.GenerateForE("tenant-a"); err == nil {
+		t.Fatalf("Expected to receive an error when the tenant's generator cannot be created, but got nothing")
+	}
+
+	func() {
+		defer func() {
+			if recover() == nil {
+				t.Fatalf("Expected GenerateFor to panic when the tenant's generator cannot be created")
+			}
+		}()
+		tenantGenerator.GenerateFor("tenant-a")
+	}()
+
+	calls = 0
+	noPanic, _ := NewTenantGenerator(0, WithFingerprintProvider(provider), WithNoPanic())
+	if cuid := noPanic.GenerateFor("tenant-a"); cuid != "" {
+		t.Fatalf("Expected an empty Cuid with WithNoPanic, but got %v", cuid)
+	}
+
+	valid, _ := NewTenantGenerator(0)
+	if cuid, err := valid.GenerateForE("tenant-a"); err != nil || !IsCuid(cuid) {
+		t.Fatalf("Expected to generate a valid Cuid, but got %v and error = %v", cuid, err)
+	}
+}
+
+func TestTenantGeneratorValidationSkipsCallbacks(t *testing.T) {
+	loggedFingerprints := []string{}
+	providerCalls := 0
+
+	tenantGenerator, err := NewTenantGenerator(
+		0,
+		WithFingerprintProvider(func() (string, error) {
+			providerCalls++
+			return "node-1", nil
+		}),
+		WithFingerprintLogger(func(fingerprint string) {
+			loggedFingerprints = append(loggedFingerprints, fingerprint)
+		}),
+	)
+	if err != nil {
+		t.Fatalf("Expected to initialize tenant generator but received error = %v", err.Error())
+	}
+
+	if len(loggedFingerprints) != 0 {
+		t.Fatalf("Expected no fingerprint to be logged before a tenant is used, but got %v", loggedFingerprints)
+	}
+
+	generator, _ := tenantGenerator.generatorFor("tenant-a")
+
+	if len(loggedFingerprints) != 1 || loggedFingerprints[0] != generator.Config().Fingerprint {
+		t.Fatalf("Expected only the tenant's fingerprint to be logged, but got %v", loggedFingerprints)
+	}
+
+	if providerCalls != 2 {
+		t.Fatalf("Expected the fingerprint provider to be invoked once for validation and once for the tenant, but got %v calls", providerCalls)
+	}
+}
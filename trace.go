@@ -0,0 +1,44 @@
+package cuid2
+
+import (
+	"context"
+	"fmt"
+)
+
+// A function that extracts a trace id from a context, used by
+// GenerateFromContext to correlate Cuids with traces.
+//
+// This keeps the package free of any tracing dependencies, e.g. an
+// OpenTelemetry extractor could return
+// trace.SpanContextFromContext(ctx).TraceID().String().
+func WithTraceIdExtractor(extractor func(ctx context.Context) (string, bool)) Option {
+	return func(config *Config) error {
+		if extractor == nil {
+			return fmt.Errorf("Error: the provided trace id extractor must not be nil")
+		}
+		config.TraceIdExtractor = extractor
+		return nil
+	}
+}
+
+// Generates a new Cuid, folding the trace id of the given context into its
+// entropy when a trace id extractor is configured and a trace id is present.
+// Otherwise, it behaves like Generate.
+//
+// The trace id is hashed along with the rest of the entropy, so the result is
+// still a valid Cuid, but the trace id cannot be recovered from it.
+func (g *Generator) GenerateFromContext(ctx context.Context) string {
+	traceId := ""
+	if g.config.TraceIdExtractor != nil {
+		if extractedTraceId, ok := g.config.TraceIdExtractor(ctx); ok {
+			traceId = extractedTraceId
+		}
+	}
+
+	cuid, err := g.generateFiltered(g.config.TimeFunc(), traceId)
+	if err != nil {
+		return g.handleGenerateError(err)
+	}
+
+	return cuid
+}
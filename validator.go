@@ -0,0 +1,135 @@
+package cuid2
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Validates ids against a configured set of accepted formats, consolidating
+// validation policy into a single injectable object
+type Validator struct {
+	acceptPlain    bool
+	prefixes       []string
+	checksumLength int
+}
+
+type ValidatorOption func(*Validator) error
+
+// Creates a new validator that accepts the formats enabled by the given
+// options. Plain Cuids are accepted when no prefixes are provided.
+func NewValidator(options ...ValidatorOption) (*Validator, error) {
+	validator := &Validator{}
+
+	for _, option := range options {
+		if option != nil {
+			if applyErr := option(validator); applyErr != nil {
+				return nil, applyErr
+			}
+		}
+	}
+
+	if !validator.acceptPlain && len(validator.prefixes) == 0 {
+		validator.acceptPlain = true
+	}
+
+	return validator, nil
+}
+
+// Accepts plain Cuids with no prefix
+func AcceptPlain() ValidatorOption {
+	return func(validator *Validator) error {
+		validator.acceptPlain = true
+		return nil
+	}
+}
+
+// Accepts Cuids that start with one of the given prefixes, e.g. "user_"
+func AcceptPrefixes(prefixes ...string) ValidatorOption {
+	return func(validator *Validator) error {
+		for _, prefix := range prefixes {
+			if len(prefix) == 0 {
+				return fmt.Errorf("Error: accepted prefixes must not be empty")
+			}
+		}
+		validator.prefixes = append(validator.prefixes, prefixes...)
+		return nil
+	}
+}
+
+// Requires accepted Cuids to end with a valid checksum of the given width, as
+// generated with WithChecksumLength
+func AcceptChecksummed(length int) ValidatorOption {
+	return func(validator *Validator) error {
+		if length < MinChecksumLength || length > MaxChecksumLength {
+			return fmt.Errorf("Error: the checksum length must be between %v and %v", MinChecksumLength, MaxChecksumLength)
+		}
+		validator.checksumLength = length
+		return nil
+	}
+}
+
+// Checks whether the id matches any of the accepted formats
+func (v *Validator) Valid(id string) bool {
+	return v.Explain(id) == nil
+}
+
+// Returns an error describing why the id does not match any of the accepted
+// formats, or nil if it is valid
+func (v *Validator) Explain(id string) error {
+	var err error
+
+	for _, prefix := range v.prefixes {
+		if strings.HasPrefix(id, prefix) {
+			if err = v.explainCuid(id[len(prefix):]); err == nil {
+				return nil
+			}
+		}
+	}
+
+	if v.acceptPlain {
+		return v.explainCuid(id)
+	}
+
+	if err == nil {
+		return fmt.Errorf("Error: id (%v) does not start with an accepted prefix", id)
+	}
+
+	return err
+}
+
+func (v *Validator) explainCuid(cuid string) error {
+	if _, err := Parse(cuid); err != nil {
+		return err
+	}
+
+	if v.checksumLength > 0 && !VerifyChecksum(cuid, v.checksumLength) {
+		return fmt.Errorf("Error: Cuid (%v) does not have a valid checksum", cuid)
+	}
+
+	return nil
+}
+
+// Configures the predicate used by Generator.Validate, so that validation
+// policy is configured alongside generation, e.g. by passing the Valid method
+// of a Validator.
+//
+// By default, Generator.Validate uses IsCuid
+func WithValidator(validate func(id string) bool) Option {
+	return func(config *Config) error {
+		if validate == nil {
+			return fmt.Errorf("Error: the provided validator must not be nil")
+		}
+		config.ValidateFunc = validate
+		return nil
+	}
+}
+
+// Checks whether an id is accepted by the generator's validator, as configured
+// with WithValidator
+func (g *Generator) Validate(id string) bool {
+	if g.config.ValidateFunc == nil {
+		return IsCuid(id)
+	}
+
+	return g.config.ValidateFunc(id)
+}
@@ -0,0 +1,75 @@
+package cuid2
+
+import (
+	"testing"
+)
+
+func TestValidator(t *testing.T) {
+	validator, err := NewValidator(AcceptPlain(), AcceptPrefixes("user_", "org_"))
+	if err != nil {
+		t.Fatalf("Expected to initialize validator but received error = %v", err.Error())
+	}
+
+	testCases := map[string]bool{
+		Generate():           true,  // Plain
+		"user_" + Generate(): true,  // Accepted prefix
+		"org_" + Generate():  true,  // Accepted prefix
+		"team_" + Generate(): false, // Unknown prefix
+		"user_aaaaDLL":       false, // Invalid Cuid after prefix
+		"aaaaDLL":            false, // Invalid plain Cuid
+	}
+
+	for testCase, expected := range testCases {
+		if validator.Valid(testCase) != expected {
+			t.Fatalf("Expected Valid(%v) to be %v, but got %v: %v", testCase, expected, !expected, validator.Explain(testCase))
+		}
+	}
+
+	prefixOnly, _ := NewValidator(AcceptPrefixes("user_"))
+	if prefixOnly.Valid(Generate()) {
+		t.Fatalf("Expected a prefix-only validator to reject plain Cuids")
+	}
+
+	if err := prefixOnly.Explain(Generate()); err == nil {
+		t.Fatalf("Expected an explanation for rejecting a plain Cuid, but got nothing")
+	}
+
+	checksummed, _ := NewValidator(AcceptChecksummed(2))
+	generate, _ := Init(WithChecksumLength(2))
+	if cuid := generate(); !checksummed.Valid(cuid) {
+		t.Fatalf("Expected a checksum validator to accept %v: %v", cuid, checksummed.Explain(cuid))
+	}
+
+	if _, err := NewValidator(AcceptChecksummed(3)); err == nil {
+		t.Fatalf("Expected to receive an error for an invalid checksum length, but got nothing")
+	}
+
+	if _, err := NewValidator(AcceptPrefixes("")); err == nil {
+		t.Fatalf("Expected to receive an error for an empty prefix, but got nothing")
+	}
+}
+
+func TestGeneratorValidate(t *testing.T) {
+	generator, err := NewGenerator()
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if !generator.Validate(generator.Generate()) || generator.Validate("aaaaDLL") {
+		t.Fatalf("Expected the default validator to behave like IsCuid")
+	}
+
+	validator, _ := NewValidator(AcceptPrefixes("user_"))
+	prefixed, err := NewGenerator(WithValidator(validator.Valid))
+	if err != nil {
+		t.Fatalf("Expected to initialize cuid2 generator but received error = %v", err.Error())
+	}
+
+	if cuid := prefixed.Generate(); prefixed.Validate(cuid) || !prefixed.Validate("user_"+cuid) {
+		t.Fatalf("Expected the configured validator to only accept prefixed Cuids")
+	}
+
+	if _, err := Init(WithValidator(nil)); err == nil {
+		t.Fatalf("Expected to receive an error for a nil validator, but got nothing")
+	}
+}